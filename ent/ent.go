@@ -6,10 +6,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"pollAppNew/ent/accesstoken"
+	"pollAppNew/ent/group"
+	"pollAppNew/ent/identity"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
+	"pollAppNew/ent/recoverycode"
+	"pollAppNew/ent/session"
 	"pollAppNew/ent/user"
+	"pollAppNew/ent/usertoken"
 	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
 	"reflect"
 	"sync"
 
@@ -76,10 +83,17 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			poll.Table:       poll.ValidColumn,
-			polloption.Table: polloption.ValidColumn,
-			user.Table:       user.ValidColumn,
-			vote.Table:       vote.ValidColumn,
+			accesstoken.Table:  accesstoken.ValidColumn,
+			group.Table:        group.ValidColumn,
+			identity.Table:     identity.ValidColumn,
+			poll.Table:         poll.ValidColumn,
+			polloption.Table:   polloption.ValidColumn,
+			recoverycode.Table: recoverycode.ValidColumn,
+			session.Table:      session.ValidColumn,
+			user.Table:         user.ValidColumn,
+			usertoken.Table:    usertoken.ValidColumn,
+			vote.Table:         vote.ValidColumn,
+			votehistory.Table:  votehistory.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
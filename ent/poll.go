@@ -7,6 +7,7 @@ import (
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/user"
 	"strings"
+	"time"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -17,10 +18,38 @@ type Poll struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// Title holds the value of the "title" field.
 	Title string `json:"title,omitempty"`
 	// CreatorID holds the value of the "creator_id" field.
 	CreatorID int `json:"creator_id,omitempty"`
+	// Status holds the value of the "status" field.
+	Status poll.Status `json:"status,omitempty"`
+	// OpensAt holds the value of the "opens_at" field.
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	// ClosesAt holds the value of the "closes_at" field.
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// ClosedAt holds the value of the "closed_at" field.
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	// Method holds the value of the "method" field.
+	Method poll.Method `json:"method,omitempty"`
+	// MinChoices holds the value of the "min_choices" field.
+	MinChoices int `json:"min_choices,omitempty"`
+	// MaxChoices holds the value of the "max_choices" field.
+	MaxChoices int `json:"max_choices,omitempty"`
+	// ScoreMin holds the value of the "score_min" field.
+	ScoreMin int `json:"score_min,omitempty"`
+	// ScoreMax holds the value of the "score_max" field.
+	ScoreMax int `json:"score_max,omitempty"`
+	// AllowVoteChanges holds the value of the "allow_vote_changes" field.
+	AllowVoteChanges bool `json:"allow_vote_changes,omitempty"`
+	// Visibility holds the value of the "visibility" field.
+	Visibility poll.Visibility `json:"visibility,omitempty"`
+	// ResultsPolicy holds the value of the "results_policy" field.
+	ResultsPolicy poll.ResultsPolicy `json:"results_policy,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PollQuery when eager-loading is set.
 	Edges        PollEdges `json:"edges"`
@@ -35,9 +64,15 @@ type PollEdges struct {
 	Options []*PollOption `json:"options,omitempty"`
 	// Votes holds the value of the votes edge.
 	Votes []*Vote `json:"votes,omitempty"`
+	// VoteHistory holds the value of the vote_history edge.
+	VoteHistory []*VoteHistory `json:"vote_history,omitempty"`
+	// InvitedUsers holds the value of the invited_users edge.
+	InvitedUsers []*User `json:"invited_users,omitempty"`
+	// InvitedGroups holds the value of the invited_groups edge.
+	InvitedGroups []*Group `json:"invited_groups,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [3]bool
+	loadedTypes [6]bool
 }
 
 // CreatorOrErr returns the Creator value or an error if the edge
@@ -69,15 +104,46 @@ func (e PollEdges) VotesOrErr() ([]*Vote, error) {
 	return nil, &NotLoadedError{edge: "votes"}
 }
 
+// VoteHistoryOrErr returns the VoteHistory value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) VoteHistoryOrErr() ([]*VoteHistory, error) {
+	if e.loadedTypes[3] {
+		return e.VoteHistory, nil
+	}
+	return nil, &NotLoadedError{edge: "vote_history"}
+}
+
+// InvitedUsersOrErr returns the InvitedUsers value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InvitedUsersOrErr() ([]*User, error) {
+	if e.loadedTypes[4] {
+		return e.InvitedUsers, nil
+	}
+	return nil, &NotLoadedError{edge: "invited_users"}
+}
+
+// InvitedGroupsOrErr returns the InvitedGroups value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InvitedGroupsOrErr() ([]*Group, error) {
+	if e.loadedTypes[5] {
+		return e.InvitedGroups, nil
+	}
+	return nil, &NotLoadedError{edge: "invited_groups"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Poll) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case poll.FieldID, poll.FieldCreatorID:
+		case poll.FieldAllowVoteChanges:
+			values[i] = new(sql.NullBool)
+		case poll.FieldID, poll.FieldCreatorID, poll.FieldMinChoices, poll.FieldMaxChoices, poll.FieldScoreMin, poll.FieldScoreMax:
 			values[i] = new(sql.NullInt64)
-		case poll.FieldTitle:
+		case poll.FieldTitle, poll.FieldStatus, poll.FieldMethod, poll.FieldVisibility, poll.FieldResultsPolicy:
 			values[i] = new(sql.NullString)
+		case poll.FieldCreatedAt, poll.FieldUpdatedAt, poll.FieldOpensAt, poll.FieldClosesAt, poll.FieldClosedAt:
+			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -99,6 +165,18 @@ func (po *Poll) assignValues(columns []string, values []any) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			po.ID = int(value.Int64)
+		case poll.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				po.CreatedAt = value.Time
+			}
+		case poll.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				po.UpdatedAt = value.Time
+			}
 		case poll.FieldTitle:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field title", values[i])
@@ -111,6 +189,81 @@ func (po *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				po.CreatorID = int(value.Int64)
 			}
+		case poll.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				po.Status = poll.Status(value.String)
+			}
+		case poll.FieldOpensAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field opens_at", values[i])
+			} else if value.Valid {
+				po.OpensAt = new(time.Time)
+				*po.OpensAt = value.Time
+			}
+		case poll.FieldClosesAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closes_at", values[i])
+			} else if value.Valid {
+				po.ClosesAt = new(time.Time)
+				*po.ClosesAt = value.Time
+			}
+		case poll.FieldClosedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closed_at", values[i])
+			} else if value.Valid {
+				po.ClosedAt = new(time.Time)
+				*po.ClosedAt = value.Time
+			}
+		case poll.FieldMethod:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field method", values[i])
+			} else if value.Valid {
+				po.Method = poll.Method(value.String)
+			}
+		case poll.FieldMinChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_choices", values[i])
+			} else if value.Valid {
+				po.MinChoices = int(value.Int64)
+			}
+		case poll.FieldMaxChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_choices", values[i])
+			} else if value.Valid {
+				po.MaxChoices = int(value.Int64)
+			}
+		case poll.FieldScoreMin:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score_min", values[i])
+			} else if value.Valid {
+				po.ScoreMin = int(value.Int64)
+			}
+		case poll.FieldScoreMax:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score_max", values[i])
+			} else if value.Valid {
+				po.ScoreMax = int(value.Int64)
+			}
+		case poll.FieldAllowVoteChanges:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field allow_vote_changes", values[i])
+			} else if value.Valid {
+				po.AllowVoteChanges = value.Bool
+			}
+		case poll.FieldVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field visibility", values[i])
+			} else if value.Valid {
+				po.Visibility = poll.Visibility(value.String)
+			}
+		case poll.FieldResultsPolicy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field results_policy", values[i])
+			} else if value.Valid {
+				po.ResultsPolicy = poll.ResultsPolicy(value.String)
+			}
 		default:
 			po.selectValues.Set(columns[i], values[i])
 		}
@@ -139,6 +292,21 @@ func (po *Poll) QueryVotes() *VoteQuery {
 	return NewPollClient(po.config).QueryVotes(po)
 }
 
+// QueryVoteHistory queries the "vote_history" edge of the Poll entity.
+func (po *Poll) QueryVoteHistory() *VoteHistoryQuery {
+	return NewPollClient(po.config).QueryVoteHistory(po)
+}
+
+// QueryInvitedUsers queries the "invited_users" edge of the Poll entity.
+func (po *Poll) QueryInvitedUsers() *UserQuery {
+	return NewPollClient(po.config).QueryInvitedUsers(po)
+}
+
+// QueryInvitedGroups queries the "invited_groups" edge of the Poll entity.
+func (po *Poll) QueryInvitedGroups() *GroupQuery {
+	return NewPollClient(po.config).QueryInvitedGroups(po)
+}
+
 // Update returns a builder for updating this Poll.
 // Note that you need to call Poll.Unwrap() before calling this method if this Poll
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -162,11 +330,59 @@ func (po *Poll) String() string {
 	var builder strings.Builder
 	builder.WriteString("Poll(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", po.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(po.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(po.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
 	builder.WriteString("title=")
 	builder.WriteString(po.Title)
 	builder.WriteString(", ")
 	builder.WriteString("creator_id=")
 	builder.WriteString(fmt.Sprintf("%v", po.CreatorID))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", po.Status))
+	builder.WriteString(", ")
+	if v := po.OpensAt; v != nil {
+		builder.WriteString("opens_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := po.ClosesAt; v != nil {
+		builder.WriteString("closes_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := po.ClosedAt; v != nil {
+		builder.WriteString("closed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("method=")
+	builder.WriteString(fmt.Sprintf("%v", po.Method))
+	builder.WriteString(", ")
+	builder.WriteString("min_choices=")
+	builder.WriteString(fmt.Sprintf("%v", po.MinChoices))
+	builder.WriteString(", ")
+	builder.WriteString("max_choices=")
+	builder.WriteString(fmt.Sprintf("%v", po.MaxChoices))
+	builder.WriteString(", ")
+	builder.WriteString("score_min=")
+	builder.WriteString(fmt.Sprintf("%v", po.ScoreMin))
+	builder.WriteString(", ")
+	builder.WriteString("score_max=")
+	builder.WriteString(fmt.Sprintf("%v", po.ScoreMax))
+	builder.WriteString(", ")
+	builder.WriteString("allow_vote_changes=")
+	builder.WriteString(fmt.Sprintf("%v", po.AllowVoteChanges))
+	builder.WriteString(", ")
+	builder.WriteString("visibility=")
+	builder.WriteString(fmt.Sprintf("%v", po.Visibility))
+	builder.WriteString(", ")
+	builder.WriteString("results_policy=")
+	builder.WriteString(fmt.Sprintf("%v", po.ResultsPolicy))
 	builder.WriteByte(')')
 	return builder.String()
 }
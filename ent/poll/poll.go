@@ -3,6 +3,9 @@
 package poll
 
 import (
+	"fmt"
+	"time"
+
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 )
@@ -12,16 +15,50 @@ const (
 	Label = "poll"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
 	// FieldTitle holds the string denoting the title field in the database.
 	FieldTitle = "title"
 	// FieldCreatorID holds the string denoting the creator_id field in the database.
 	FieldCreatorID = "creator_id"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldOpensAt holds the string denoting the opens_at field in the database.
+	FieldOpensAt = "opens_at"
+	// FieldClosesAt holds the string denoting the closes_at field in the database.
+	FieldClosesAt = "closes_at"
+	// FieldClosedAt holds the string denoting the closed_at field in the database.
+	FieldClosedAt = "closed_at"
+	// FieldMethod holds the string denoting the method field in the database.
+	FieldMethod = "method"
+	// FieldMinChoices holds the string denoting the min_choices field in the database.
+	FieldMinChoices = "min_choices"
+	// FieldMaxChoices holds the string denoting the max_choices field in the database.
+	FieldMaxChoices = "max_choices"
+	// FieldScoreMin holds the string denoting the score_min field in the database.
+	FieldScoreMin = "score_min"
+	// FieldScoreMax holds the string denoting the score_max field in the database.
+	FieldScoreMax = "score_max"
+	// FieldAllowVoteChanges holds the string denoting the allow_vote_changes field in the database.
+	FieldAllowVoteChanges = "allow_vote_changes"
+	// FieldVisibility holds the string denoting the visibility field in the database.
+	FieldVisibility = "visibility"
+	// FieldResultsPolicy holds the string denoting the results_policy field in the database.
+	FieldResultsPolicy = "results_policy"
 	// EdgeCreator holds the string denoting the creator edge name in mutations.
 	EdgeCreator = "creator"
 	// EdgeOptions holds the string denoting the options edge name in mutations.
 	EdgeOptions = "options"
 	// EdgeVotes holds the string denoting the votes edge name in mutations.
 	EdgeVotes = "votes"
+	// EdgeVoteHistory holds the string denoting the vote_history edge name in mutations.
+	EdgeVoteHistory = "vote_history"
+	// EdgeInvitedUsers holds the string denoting the invited_users edge name in mutations.
+	EdgeInvitedUsers = "invited_users"
+	// EdgeInvitedGroups holds the string denoting the invited_groups edge name in mutations.
+	EdgeInvitedGroups = "invited_groups"
 	// Table holds the table name of the poll in the database.
 	Table = "polls"
 	// CreatorTable is the table that holds the creator relation/edge.
@@ -45,15 +82,55 @@ const (
 	VotesInverseTable = "votes"
 	// VotesColumn is the table column denoting the votes relation/edge.
 	VotesColumn = "poll_id"
+	// VoteHistoryTable is the table that holds the vote_history relation/edge.
+	VoteHistoryTable = "vote_histories"
+	// VoteHistoryInverseTable is the table name for the VoteHistory entity.
+	// It exists in this package in order to avoid circular dependency with the "votehistory" package.
+	VoteHistoryInverseTable = "vote_histories"
+	// VoteHistoryColumn is the table column denoting the vote_history relation/edge.
+	VoteHistoryColumn = "poll_id"
+	// InvitedUsersTable is the table that holds the invited_users relation/edge. The primary key declared below.
+	InvitedUsersTable = "poll_invited_users"
+	// InvitedUsersInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	InvitedUsersInverseTable = "users"
+	// InvitedGroupsTable is the table that holds the invited_groups relation/edge. The primary key declared below.
+	InvitedGroupsTable = "poll_invited_groups"
+	// InvitedGroupsInverseTable is the table name for the Group entity.
+	// It exists in this package in order to avoid circular dependency with the "group" package.
+	InvitedGroupsInverseTable = "groups"
 )
 
 // Columns holds all SQL columns for poll fields.
 var Columns = []string{
 	FieldID,
+	FieldCreatedAt,
+	FieldUpdatedAt,
 	FieldTitle,
 	FieldCreatorID,
+	FieldStatus,
+	FieldOpensAt,
+	FieldClosesAt,
+	FieldClosedAt,
+	FieldMethod,
+	FieldMinChoices,
+	FieldMaxChoices,
+	FieldScoreMin,
+	FieldScoreMax,
+	FieldAllowVoteChanges,
+	FieldVisibility,
+	FieldResultsPolicy,
 }
 
+var (
+	// InvitedUsersPrimaryKey and InvitedUsersColumn2 are the table columns denoting the
+	// primary key for the invited_users relation (M2M).
+	InvitedUsersPrimaryKey = []string{"poll_id", "user_id"}
+	// InvitedGroupsPrimaryKey and InvitedGroupsColumn2 are the table columns denoting the
+	// primary key for the invited_groups relation (M2M).
+	InvitedGroupsPrimaryKey = []string{"poll_id", "group_id"}
+)
+
 // ValidColumn reports if the column name is valid (part of the table columns).
 func ValidColumn(column string) bool {
 	for i := range Columns {
@@ -65,10 +142,143 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
 	// TitleValidator is a validator for the "title" field. It is called by the builders before save.
 	TitleValidator func(string) error
+	// DefaultMinChoices holds the default value on creation for the "min_choices" field.
+	DefaultMinChoices int
+	// MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	MinChoicesValidator func(int) error
+	// DefaultMaxChoices holds the default value on creation for the "max_choices" field.
+	DefaultMaxChoices int
+	// MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	MaxChoicesValidator func(int) error
+	// DefaultScoreMin holds the default value on creation for the "score_min" field.
+	DefaultScoreMin int
+	// DefaultScoreMax holds the default value on creation for the "score_max" field.
+	DefaultScoreMax int
+	// DefaultAllowVoteChanges holds the default value on creation for the "allow_vote_changes" field.
+	DefaultAllowVoteChanges bool
+)
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// StatusOpen is the default value of the Status enum.
+const DefaultStatus = StatusOpen
+
+// Status values.
+const (
+	StatusDraft     Status = "draft"
+	StatusScheduled Status = "scheduled"
+	StatusOpen      Status = "open"
+	StatusClosed    Status = "closed"
+	StatusArchived  Status = "archived"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusDraft, StatusScheduled, StatusOpen, StatusClosed, StatusArchived:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for status field: %q", s)
+	}
+}
+
+// Method defines the type for the "method" enum field.
+type Method string
+
+// MethodPlurality is the default value of the Method enum.
+const DefaultMethod = MethodPlurality
+
+// Method values.
+const (
+	MethodPlurality Method = "plurality"
+	MethodIrv       Method = "irv"
+	MethodSchulze   Method = "schulze"
+	MethodScore     Method = "score"
+	MethodStar      Method = "star"
 )
 
+func (m Method) String() string {
+	return string(m)
+}
+
+// MethodValidator is a validator for the "method" field enum values. It is called by the builders before save.
+func MethodValidator(m Method) error {
+	switch m {
+	case MethodPlurality, MethodIrv, MethodSchulze, MethodScore, MethodStar:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for method field: %q", m)
+	}
+}
+
+// Visibility defines the type for the "visibility" enum field.
+type Visibility string
+
+// VisibilityPublic is the default value of the Visibility enum.
+const DefaultVisibility = VisibilityPublic
+
+// Visibility values.
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityUnlisted Visibility = "unlisted"
+	VisibilityPrivate  Visibility = "private"
+)
+
+func (v Visibility) String() string {
+	return string(v)
+}
+
+// VisibilityValidator is a validator for the "visibility" field enum values. It is called by the builders before save.
+func VisibilityValidator(v Visibility) error {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for visibility field: %q", v)
+	}
+}
+
+// ResultsPolicy defines the type for the "results_policy" enum field.
+type ResultsPolicy string
+
+// ResultsPolicyAlways is the default value of the ResultsPolicy enum.
+const DefaultResultsPolicy = ResultsPolicyAlways
+
+// ResultsPolicy values.
+const (
+	ResultsPolicyAlways     ResultsPolicy = "always"
+	ResultsPolicyAfterVote  ResultsPolicy = "after_vote"
+	ResultsPolicyAfterClose ResultsPolicy = "after_close"
+	ResultsPolicyOwnerOnly  ResultsPolicy = "owner_only"
+)
+
+func (rp ResultsPolicy) String() string {
+	return string(rp)
+}
+
+// ResultsPolicyValidator is a validator for the "results_policy" field enum values. It is called by the builders before save.
+func ResultsPolicyValidator(rp ResultsPolicy) error {
+	switch rp {
+	case ResultsPolicyAlways, ResultsPolicyAfterVote, ResultsPolicyAfterClose, ResultsPolicyOwnerOnly:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for results_policy field: %q", rp)
+	}
+}
+
 // OrderOption defines the ordering options for the Poll queries.
 type OrderOption func(*sql.Selector)
 
@@ -77,6 +287,16 @@ func ByID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldID, opts...).ToFunc()
 }
 
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
 // ByTitle orders the results by the title field.
 func ByTitle(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTitle, opts...).ToFunc()
@@ -87,6 +307,66 @@ func ByCreatorID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatorID, opts...).ToFunc()
 }
 
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByOpensAt orders the results by the opens_at field.
+func ByOpensAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOpensAt, opts...).ToFunc()
+}
+
+// ByClosesAt orders the results by the closes_at field.
+func ByClosesAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosesAt, opts...).ToFunc()
+}
+
+// ByClosedAt orders the results by the closed_at field.
+func ByClosedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosedAt, opts...).ToFunc()
+}
+
+// ByMethod orders the results by the method field.
+func ByMethod(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMethod, opts...).ToFunc()
+}
+
+// ByMinChoices orders the results by the min_choices field.
+func ByMinChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinChoices, opts...).ToFunc()
+}
+
+// ByMaxChoices orders the results by the max_choices field.
+func ByMaxChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxChoices, opts...).ToFunc()
+}
+
+// ByScoreMin orders the results by the score_min field.
+func ByScoreMin(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScoreMin, opts...).ToFunc()
+}
+
+// ByScoreMax orders the results by the score_max field.
+func ByScoreMax(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScoreMax, opts...).ToFunc()
+}
+
+// ByAllowVoteChanges orders the results by the allow_vote_changes field.
+func ByAllowVoteChanges(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAllowVoteChanges, opts...).ToFunc()
+}
+
+// ByVisibility orders the results by the visibility field.
+func ByVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVisibility, opts...).ToFunc()
+}
+
+// ByResultsPolicy orders the results by the results_policy field.
+func ByResultsPolicy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsPolicy, opts...).ToFunc()
+}
+
 // ByCreatorField orders the results by creator field.
 func ByCreatorField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -121,6 +401,48 @@ func ByVotes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newVotesStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByVoteHistoryCount orders the results by vote_history count.
+func ByVoteHistoryCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newVoteHistoryStep(), opts...)
+	}
+}
+
+// ByVoteHistory orders the results by vote_history terms.
+func ByVoteHistory(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newVoteHistoryStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByInvitedUsersCount orders the results by invited_users count.
+func ByInvitedUsersCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInvitedUsersStep(), opts...)
+	}
+}
+
+// ByInvitedUsers orders the results by invited_users terms.
+func ByInvitedUsers(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInvitedUsersStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByInvitedGroupsCount orders the results by invited_groups count.
+func ByInvitedGroupsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInvitedGroupsStep(), opts...)
+	}
+}
+
+// ByInvitedGroups orders the results by invited_groups terms.
+func ByInvitedGroups(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInvitedGroupsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newCreatorStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -142,3 +464,24 @@ func newVotesStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, VotesTable, VotesColumn),
 	)
 }
+func newVoteHistoryStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(VoteHistoryInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, VoteHistoryTable, VoteHistoryColumn),
+	)
+}
+func newInvitedUsersStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InvitedUsersInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, InvitedUsersTable, InvitedUsersPrimaryKey...),
+	)
+}
+func newInvitedGroupsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InvitedGroupsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, InvitedGroupsTable, InvitedGroupsPrimaryKey...),
+	)
+}
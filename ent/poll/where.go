@@ -4,6 +4,7 @@ package poll
 
 import (
 	"pollAppNew/ent/predicate"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -54,6 +55,16 @@ func IDLTE(id int) predicate.Poll {
 	return predicate.Poll(sql.FieldLTE(FieldID, id))
 }
 
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
 // Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
 func Title(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldTitle, v))
@@ -64,6 +75,126 @@ func CreatorID(v int) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatorID, v))
 }
 
+// OpensAt applies equality check predicate on the "opens_at" field. It's identical to OpensAtEQ.
+func OpensAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// ClosesAt applies equality check predicate on the "closes_at" field. It's identical to ClosesAtEQ.
+func ClosesAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// ClosedAt applies equality check predicate on the "closed_at" field. It's identical to ClosedAtEQ.
+func ClosedAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosedAt, v))
+}
+
+// MinChoices applies equality check predicate on the "min_choices" field. It's identical to MinChoicesEQ.
+func MinChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MaxChoices applies equality check predicate on the "max_choices" field. It's identical to MaxChoicesEQ.
+func MaxChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// ScoreMin applies equality check predicate on the "score_min" field. It's identical to ScoreMinEQ.
+func ScoreMin(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMin, v))
+}
+
+// ScoreMax applies equality check predicate on the "score_max" field. It's identical to ScoreMaxEQ.
+func ScoreMax(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMax, v))
+}
+
+// AllowVoteChanges applies equality check predicate on the "allow_vote_changes" field. It's identical to AllowVoteChangesEQ.
+func AllowVoteChanges(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowVoteChanges, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
 // TitleEQ applies the EQ predicate on the "title" field.
 func TitleEQ(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldTitle, v))
@@ -149,6 +280,406 @@ func CreatorIDNotIn(vs ...int) predicate.Poll {
 	return predicate.Poll(sql.FieldNotIn(FieldCreatorID, vs...))
 }
 
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// OpensAtEQ applies the EQ predicate on the "opens_at" field.
+func OpensAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// OpensAtNEQ applies the NEQ predicate on the "opens_at" field.
+func OpensAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOpensAt, v))
+}
+
+// OpensAtIn applies the In predicate on the "opens_at" field.
+func OpensAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOpensAt, vs...))
+}
+
+// OpensAtNotIn applies the NotIn predicate on the "opens_at" field.
+func OpensAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOpensAt, vs...))
+}
+
+// OpensAtGT applies the GT predicate on the "opens_at" field.
+func OpensAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOpensAt, v))
+}
+
+// OpensAtGTE applies the GTE predicate on the "opens_at" field.
+func OpensAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOpensAt, v))
+}
+
+// OpensAtLT applies the LT predicate on the "opens_at" field.
+func OpensAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOpensAt, v))
+}
+
+// OpensAtLTE applies the LTE predicate on the "opens_at" field.
+func OpensAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOpensAt, v))
+}
+
+// OpensAtIsNil applies the IsNil predicate on the "opens_at" field.
+func OpensAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOpensAt))
+}
+
+// OpensAtNotNil applies the NotNil predicate on the "opens_at" field.
+func OpensAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOpensAt))
+}
+
+// ClosesAtEQ applies the EQ predicate on the "closes_at" field.
+func ClosesAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// ClosesAtNEQ applies the NEQ predicate on the "closes_at" field.
+func ClosesAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosesAt, v))
+}
+
+// ClosesAtIn applies the In predicate on the "closes_at" field.
+func ClosesAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtNotIn applies the NotIn predicate on the "closes_at" field.
+func ClosesAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtGT applies the GT predicate on the "closes_at" field.
+func ClosesAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosesAt, v))
+}
+
+// ClosesAtGTE applies the GTE predicate on the "closes_at" field.
+func ClosesAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosesAt, v))
+}
+
+// ClosesAtLT applies the LT predicate on the "closes_at" field.
+func ClosesAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosesAt, v))
+}
+
+// ClosesAtLTE applies the LTE predicate on the "closes_at" field.
+func ClosesAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosesAt, v))
+}
+
+// ClosesAtIsNil applies the IsNil predicate on the "closes_at" field.
+func ClosesAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosesAt))
+}
+
+// ClosesAtNotNil applies the NotNil predicate on the "closes_at" field.
+func ClosesAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosesAt))
+}
+
+// ClosedAtEQ applies the EQ predicate on the "closed_at" field.
+func ClosedAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosedAt, v))
+}
+
+// ClosedAtNEQ applies the NEQ predicate on the "closed_at" field.
+func ClosedAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosedAt, v))
+}
+
+// ClosedAtIn applies the In predicate on the "closed_at" field.
+func ClosedAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosedAt, vs...))
+}
+
+// ClosedAtNotIn applies the NotIn predicate on the "closed_at" field.
+func ClosedAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosedAt, vs...))
+}
+
+// ClosedAtGT applies the GT predicate on the "closed_at" field.
+func ClosedAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosedAt, v))
+}
+
+// ClosedAtGTE applies the GTE predicate on the "closed_at" field.
+func ClosedAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosedAt, v))
+}
+
+// ClosedAtLT applies the LT predicate on the "closed_at" field.
+func ClosedAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosedAt, v))
+}
+
+// ClosedAtLTE applies the LTE predicate on the "closed_at" field.
+func ClosedAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosedAt, v))
+}
+
+// ClosedAtIsNil applies the IsNil predicate on the "closed_at" field.
+func ClosedAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosedAt))
+}
+
+// ClosedAtNotNil applies the NotNil predicate on the "closed_at" field.
+func ClosedAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosedAt))
+}
+
+// MethodEQ applies the EQ predicate on the "method" field.
+func MethodEQ(v Method) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMethod, v))
+}
+
+// MethodNEQ applies the NEQ predicate on the "method" field.
+func MethodNEQ(v Method) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMethod, v))
+}
+
+// MethodIn applies the In predicate on the "method" field.
+func MethodIn(vs ...Method) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMethod, vs...))
+}
+
+// MethodNotIn applies the NotIn predicate on the "method" field.
+func MethodNotIn(vs ...Method) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMethod, vs...))
+}
+
+// MinChoicesEQ applies the EQ predicate on the "min_choices" field.
+func MinChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MinChoicesNEQ applies the NEQ predicate on the "min_choices" field.
+func MinChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMinChoices, v))
+}
+
+// MinChoicesIn applies the In predicate on the "min_choices" field.
+func MinChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesNotIn applies the NotIn predicate on the "min_choices" field.
+func MinChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesGT applies the GT predicate on the "min_choices" field.
+func MinChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMinChoices, v))
+}
+
+// MinChoicesGTE applies the GTE predicate on the "min_choices" field.
+func MinChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMinChoices, v))
+}
+
+// MinChoicesLT applies the LT predicate on the "min_choices" field.
+func MinChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMinChoices, v))
+}
+
+// MinChoicesLTE applies the LTE predicate on the "min_choices" field.
+func MinChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMinChoices, v))
+}
+
+// MaxChoicesEQ applies the EQ predicate on the "max_choices" field.
+func MaxChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesNEQ applies the NEQ predicate on the "max_choices" field.
+func MaxChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesIn applies the In predicate on the "max_choices" field.
+func MaxChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesNotIn applies the NotIn predicate on the "max_choices" field.
+func MaxChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesGT applies the GT predicate on the "max_choices" field.
+func MaxChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMaxChoices, v))
+}
+
+// MaxChoicesGTE applies the GTE predicate on the "max_choices" field.
+func MaxChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMaxChoices, v))
+}
+
+// MaxChoicesLT applies the LT predicate on the "max_choices" field.
+func MaxChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMaxChoices, v))
+}
+
+// MaxChoicesLTE applies the LTE predicate on the "max_choices" field.
+func MaxChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMaxChoices, v))
+}
+
+// ScoreMinEQ applies the EQ predicate on the "score_min" field.
+func ScoreMinEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMin, v))
+}
+
+// ScoreMinNEQ applies the NEQ predicate on the "score_min" field.
+func ScoreMinNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldScoreMin, v))
+}
+
+// ScoreMinIn applies the In predicate on the "score_min" field.
+func ScoreMinIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldScoreMin, vs...))
+}
+
+// ScoreMinNotIn applies the NotIn predicate on the "score_min" field.
+func ScoreMinNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldScoreMin, vs...))
+}
+
+// ScoreMinGT applies the GT predicate on the "score_min" field.
+func ScoreMinGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldScoreMin, v))
+}
+
+// ScoreMinGTE applies the GTE predicate on the "score_min" field.
+func ScoreMinGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldScoreMin, v))
+}
+
+// ScoreMinLT applies the LT predicate on the "score_min" field.
+func ScoreMinLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldScoreMin, v))
+}
+
+// ScoreMinLTE applies the LTE predicate on the "score_min" field.
+func ScoreMinLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldScoreMin, v))
+}
+
+// ScoreMaxEQ applies the EQ predicate on the "score_max" field.
+func ScoreMaxEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMax, v))
+}
+
+// ScoreMaxNEQ applies the NEQ predicate on the "score_max" field.
+func ScoreMaxNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldScoreMax, v))
+}
+
+// ScoreMaxIn applies the In predicate on the "score_max" field.
+func ScoreMaxIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldScoreMax, vs...))
+}
+
+// ScoreMaxNotIn applies the NotIn predicate on the "score_max" field.
+func ScoreMaxNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldScoreMax, vs...))
+}
+
+// ScoreMaxGT applies the GT predicate on the "score_max" field.
+func ScoreMaxGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldScoreMax, v))
+}
+
+// ScoreMaxGTE applies the GTE predicate on the "score_max" field.
+func ScoreMaxGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldScoreMax, v))
+}
+
+// ScoreMaxLT applies the LT predicate on the "score_max" field.
+func ScoreMaxLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldScoreMax, v))
+}
+
+// ScoreMaxLTE applies the LTE predicate on the "score_max" field.
+func ScoreMaxLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldScoreMax, v))
+}
+
+// AllowVoteChangesEQ applies the EQ predicate on the "allow_vote_changes" field.
+func AllowVoteChangesEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowVoteChanges, v))
+}
+
+// AllowVoteChangesNEQ applies the NEQ predicate on the "allow_vote_changes" field.
+func AllowVoteChangesNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAllowVoteChanges, v))
+}
+
+// VisibilityEQ applies the EQ predicate on the "visibility" field.
+func VisibilityEQ(v Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVisibility, v))
+}
+
+// VisibilityNEQ applies the NEQ predicate on the "visibility" field.
+func VisibilityNEQ(v Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldVisibility, v))
+}
+
+// VisibilityIn applies the In predicate on the "visibility" field.
+func VisibilityIn(vs ...Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldVisibility, vs...))
+}
+
+// VisibilityNotIn applies the NotIn predicate on the "visibility" field.
+func VisibilityNotIn(vs ...Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldVisibility, vs...))
+}
+
+// ResultsPolicyEQ applies the EQ predicate on the "results_policy" field.
+func ResultsPolicyEQ(v ResultsPolicy) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsPolicy, v))
+}
+
+// ResultsPolicyNEQ applies the NEQ predicate on the "results_policy" field.
+func ResultsPolicyNEQ(v ResultsPolicy) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsPolicy, v))
+}
+
+// ResultsPolicyIn applies the In predicate on the "results_policy" field.
+func ResultsPolicyIn(vs ...ResultsPolicy) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsPolicy, vs...))
+}
+
+// ResultsPolicyNotIn applies the NotIn predicate on the "results_policy" field.
+func ResultsPolicyNotIn(vs ...ResultsPolicy) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsPolicy, vs...))
+}
+
 // HasCreator applies the HasEdge predicate on the "creator" edge.
 func HasCreator() predicate.Poll {
 	return predicate.Poll(func(s *sql.Selector) {
@@ -218,6 +749,75 @@ func HasVotesWith(preds ...predicate.Vote) predicate.Poll {
 	})
 }
 
+// HasVoteHistory applies the HasEdge predicate on the "vote_history" edge.
+func HasVoteHistory() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, VoteHistoryTable, VoteHistoryColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasVoteHistoryWith applies the HasEdge predicate on the "vote_history" edge with a given conditions (other predicates).
+func HasVoteHistoryWith(preds ...predicate.VoteHistory) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newVoteHistoryStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasInvitedUsers applies the HasEdge predicate on the "invited_users" edge.
+func HasInvitedUsers() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, InvitedUsersTable, InvitedUsersPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInvitedUsersWith applies the HasEdge predicate on the "invited_users" edge with a given conditions (other predicates).
+func HasInvitedUsersWith(preds ...predicate.User) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInvitedUsersStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasInvitedGroups applies the HasEdge predicate on the "invited_groups" edge.
+func HasInvitedGroups() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, InvitedGroupsTable, InvitedGroupsPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInvitedGroupsWith applies the HasEdge predicate on the "invited_groups" edge with a given conditions (other predicates).
+func HasInvitedGroupsWith(preds ...predicate.Group) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInvitedGroupsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Poll) predicate.Poll {
 	return predicate.Poll(sql.AndPredicates(predicates...))
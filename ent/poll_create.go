@@ -6,10 +6,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"pollAppNew/ent/group"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -22,6 +25,34 @@ type PollCreate struct {
 	hooks    []Hook
 }
 
+// SetCreatedAt sets the "created_at" field.
+func (pc *PollCreate) SetCreatedAt(t time.Time) *PollCreate {
+	pc.mutation.SetCreatedAt(t)
+	return pc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableCreatedAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetCreatedAt(*t)
+	}
+	return pc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (pc *PollCreate) SetUpdatedAt(t time.Time) *PollCreate {
+	pc.mutation.SetUpdatedAt(t)
+	return pc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableUpdatedAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetUpdatedAt(*t)
+	}
+	return pc
+}
+
 // SetTitle sets the "title" field.
 func (pc *PollCreate) SetTitle(s string) *PollCreate {
 	pc.mutation.SetTitle(s)
@@ -34,6 +65,174 @@ func (pc *PollCreate) SetCreatorID(i int) *PollCreate {
 	return pc
 }
 
+// SetStatus sets the "status" field.
+func (pc *PollCreate) SetStatus(po poll.Status) *PollCreate {
+	pc.mutation.SetStatus(po)
+	return pc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (pc *PollCreate) SetNillableStatus(po *poll.Status) *PollCreate {
+	if po != nil {
+		pc.SetStatus(*po)
+	}
+	return pc
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (pc *PollCreate) SetOpensAt(t time.Time) *PollCreate {
+	pc.mutation.SetOpensAt(t)
+	return pc
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableOpensAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetOpensAt(*t)
+	}
+	return pc
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (pc *PollCreate) SetClosesAt(t time.Time) *PollCreate {
+	pc.mutation.SetClosesAt(t)
+	return pc
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableClosesAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetClosesAt(*t)
+	}
+	return pc
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (pc *PollCreate) SetClosedAt(t time.Time) *PollCreate {
+	pc.mutation.SetClosedAt(t)
+	return pc
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableClosedAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetClosedAt(*t)
+	}
+	return pc
+}
+
+// SetMethod sets the "method" field.
+func (pc *PollCreate) SetMethod(po poll.Method) *PollCreate {
+	pc.mutation.SetMethod(po)
+	return pc
+}
+
+// SetNillableMethod sets the "method" field if the given value is not nil.
+func (pc *PollCreate) SetNillableMethod(po *poll.Method) *PollCreate {
+	if po != nil {
+		pc.SetMethod(*po)
+	}
+	return pc
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (pc *PollCreate) SetMinChoices(i int) *PollCreate {
+	pc.mutation.SetMinChoices(i)
+	return pc
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (pc *PollCreate) SetNillableMinChoices(i *int) *PollCreate {
+	if i != nil {
+		pc.SetMinChoices(*i)
+	}
+	return pc
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (pc *PollCreate) SetMaxChoices(i int) *PollCreate {
+	pc.mutation.SetMaxChoices(i)
+	return pc
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (pc *PollCreate) SetNillableMaxChoices(i *int) *PollCreate {
+	if i != nil {
+		pc.SetMaxChoices(*i)
+	}
+	return pc
+}
+
+// SetScoreMin sets the "score_min" field.
+func (pc *PollCreate) SetScoreMin(i int) *PollCreate {
+	pc.mutation.SetScoreMin(i)
+	return pc
+}
+
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (pc *PollCreate) SetNillableScoreMin(i *int) *PollCreate {
+	if i != nil {
+		pc.SetScoreMin(*i)
+	}
+	return pc
+}
+
+// SetScoreMax sets the "score_max" field.
+func (pc *PollCreate) SetScoreMax(i int) *PollCreate {
+	pc.mutation.SetScoreMax(i)
+	return pc
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (pc *PollCreate) SetNillableScoreMax(i *int) *PollCreate {
+	if i != nil {
+		pc.SetScoreMax(*i)
+	}
+	return pc
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (pc *PollCreate) SetAllowVoteChanges(b bool) *PollCreate {
+	pc.mutation.SetAllowVoteChanges(b)
+	return pc
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (pc *PollCreate) SetNillableAllowVoteChanges(b *bool) *PollCreate {
+	if b != nil {
+		pc.SetAllowVoteChanges(*b)
+	}
+	return pc
+}
+
+// SetVisibility sets the "visibility" field.
+func (pc *PollCreate) SetVisibility(po poll.Visibility) *PollCreate {
+	pc.mutation.SetVisibility(po)
+	return pc
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (pc *PollCreate) SetNillableVisibility(po *poll.Visibility) *PollCreate {
+	if po != nil {
+		pc.SetVisibility(*po)
+	}
+	return pc
+}
+
+// SetResultsPolicy sets the "results_policy" field.
+func (pc *PollCreate) SetResultsPolicy(pp poll.ResultsPolicy) *PollCreate {
+	pc.mutation.SetResultsPolicy(pp)
+	return pc
+}
+
+// SetNillableResultsPolicy sets the "results_policy" field if the given value is not nil.
+func (pc *PollCreate) SetNillableResultsPolicy(pp *poll.ResultsPolicy) *PollCreate {
+	if pp != nil {
+		pc.SetResultsPolicy(*pp)
+	}
+	return pc
+}
+
 // SetCreator sets the "creator" edge to the User entity.
 func (pc *PollCreate) SetCreator(u *User) *PollCreate {
 	return pc.SetCreatorID(u.ID)
@@ -69,6 +268,51 @@ func (pc *PollCreate) AddVotes(v ...*Vote) *PollCreate {
 	return pc.AddVoteIDs(ids...)
 }
 
+// AddVoteHistoryIDs adds the "vote_history" edge to the VoteHistory entity by IDs.
+func (pc *PollCreate) AddVoteHistoryIDs(ids ...int) *PollCreate {
+	pc.mutation.AddVoteHistoryIDs(ids...)
+	return pc
+}
+
+// AddVoteHistory adds the "vote_history" edges to the VoteHistory entity.
+func (pc *PollCreate) AddVoteHistory(v ...*VoteHistory) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return pc.AddVoteHistoryIDs(ids...)
+}
+
+// AddInvitedUserIDs adds the "invited_users" edge to the User entity by IDs.
+func (pc *PollCreate) AddInvitedUserIDs(ids ...int) *PollCreate {
+	pc.mutation.AddInvitedUserIDs(ids...)
+	return pc
+}
+
+// AddInvitedUsers adds the "invited_users" edges to the User entity.
+func (pc *PollCreate) AddInvitedUsers(u ...*User) *PollCreate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pc.AddInvitedUserIDs(ids...)
+}
+
+// AddInvitedGroupIDs adds the "invited_groups" edge to the Group entity by IDs.
+func (pc *PollCreate) AddInvitedGroupIDs(ids ...int) *PollCreate {
+	pc.mutation.AddInvitedGroupIDs(ids...)
+	return pc
+}
+
+// AddInvitedGroups adds the "invited_groups" edges to the Group entity.
+func (pc *PollCreate) AddInvitedGroups(g ...*Group) *PollCreate {
+	ids := make([]int, len(g))
+	for i := range g {
+		ids[i] = g[i].ID
+	}
+	return pc.AddInvitedGroupIDs(ids...)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (pc *PollCreate) Mutation() *PollMutation {
 	return pc.mutation
@@ -76,6 +320,7 @@ func (pc *PollCreate) Mutation() *PollMutation {
 
 // Save creates the Poll in the database.
 func (pc *PollCreate) Save(ctx context.Context) (*Poll, error) {
+	pc.defaults()
 	return withHooks(ctx, pc.sqlSave, pc.mutation, pc.hooks)
 }
 
@@ -101,8 +346,62 @@ func (pc *PollCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (pc *PollCreate) defaults() {
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		v := poll.DefaultCreatedAt()
+		pc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		v := poll.DefaultUpdatedAt()
+		pc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := pc.mutation.Status(); !ok {
+		v := poll.DefaultStatus
+		pc.mutation.SetStatus(v)
+	}
+	if _, ok := pc.mutation.Method(); !ok {
+		v := poll.DefaultMethod
+		pc.mutation.SetMethod(v)
+	}
+	if _, ok := pc.mutation.MinChoices(); !ok {
+		v := poll.DefaultMinChoices
+		pc.mutation.SetMinChoices(v)
+	}
+	if _, ok := pc.mutation.MaxChoices(); !ok {
+		v := poll.DefaultMaxChoices
+		pc.mutation.SetMaxChoices(v)
+	}
+	if _, ok := pc.mutation.ScoreMin(); !ok {
+		v := poll.DefaultScoreMin
+		pc.mutation.SetScoreMin(v)
+	}
+	if _, ok := pc.mutation.ScoreMax(); !ok {
+		v := poll.DefaultScoreMax
+		pc.mutation.SetScoreMax(v)
+	}
+	if _, ok := pc.mutation.AllowVoteChanges(); !ok {
+		v := poll.DefaultAllowVoteChanges
+		pc.mutation.SetAllowVoteChanges(v)
+	}
+	if _, ok := pc.mutation.Visibility(); !ok {
+		v := poll.DefaultVisibility
+		pc.mutation.SetVisibility(v)
+	}
+	if _, ok := pc.mutation.ResultsPolicy(); !ok {
+		v := poll.DefaultResultsPolicy
+		pc.mutation.SetResultsPolicy(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (pc *PollCreate) check() error {
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Poll.created_at"`)}
+	}
+	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Poll.updated_at"`)}
+	}
 	if _, ok := pc.mutation.Title(); !ok {
 		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "Poll.title"`)}
 	}
@@ -114,6 +413,63 @@ func (pc *PollCreate) check() error {
 	if _, ok := pc.mutation.CreatorID(); !ok {
 		return &ValidationError{Name: "creator_id", err: errors.New(`ent: missing required field "Poll.creator_id"`)}
 	}
+	if _, ok := pc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Poll.status"`)}
+	}
+	if v, ok := pc.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Method(); !ok {
+		return &ValidationError{Name: "method", err: errors.New(`ent: missing required field "Poll.method"`)}
+	}
+	if v, ok := pc.mutation.Method(); ok {
+		if err := poll.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "Poll.method": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.MinChoices(); !ok {
+		return &ValidationError{Name: "min_choices", err: errors.New(`ent: missing required field "Poll.min_choices"`)}
+	}
+	if v, ok := pc.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.MaxChoices(); !ok {
+		return &ValidationError{Name: "max_choices", err: errors.New(`ent: missing required field "Poll.max_choices"`)}
+	}
+	if v, ok := pc.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.ScoreMin(); !ok {
+		return &ValidationError{Name: "score_min", err: errors.New(`ent: missing required field "Poll.score_min"`)}
+	}
+	if _, ok := pc.mutation.ScoreMax(); !ok {
+		return &ValidationError{Name: "score_max", err: errors.New(`ent: missing required field "Poll.score_max"`)}
+	}
+	if _, ok := pc.mutation.AllowVoteChanges(); !ok {
+		return &ValidationError{Name: "allow_vote_changes", err: errors.New(`ent: missing required field "Poll.allow_vote_changes"`)}
+	}
+	if _, ok := pc.mutation.Visibility(); !ok {
+		return &ValidationError{Name: "visibility", err: errors.New(`ent: missing required field "Poll.visibility"`)}
+	}
+	if v, ok := pc.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.ResultsPolicy(); !ok {
+		return &ValidationError{Name: "results_policy", err: errors.New(`ent: missing required field "Poll.results_policy"`)}
+	}
+	if v, ok := pc.mutation.ResultsPolicy(); ok {
+		if err := poll.ResultsPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "results_policy", err: fmt.Errorf(`ent: validator failed for field "Poll.results_policy": %w`, err)}
+		}
+	}
 	if len(pc.mutation.CreatorIDs()) == 0 {
 		return &ValidationError{Name: "creator", err: errors.New(`ent: missing required edge "Poll.creator"`)}
 	}
@@ -143,10 +499,66 @@ func (pc *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_node = &Poll{config: pc.config}
 		_spec = sqlgraph.NewCreateSpec(poll.Table, sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt))
 	)
+	if value, ok := pc.mutation.CreatedAt(); ok {
+		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := pc.mutation.UpdatedAt(); ok {
+		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
 	if value, ok := pc.mutation.Title(); ok {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 		_node.Title = value
 	}
+	if value, ok := pc.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := pc.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+		_node.OpensAt = &value
+	}
+	if value, ok := pc.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+		_node.ClosesAt = &value
+	}
+	if value, ok := pc.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+		_node.ClosedAt = &value
+	}
+	if value, ok := pc.mutation.Method(); ok {
+		_spec.SetField(poll.FieldMethod, field.TypeEnum, value)
+		_node.Method = value
+	}
+	if value, ok := pc.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+		_node.MinChoices = value
+	}
+	if value, ok := pc.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+		_node.MaxChoices = value
+	}
+	if value, ok := pc.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+		_node.ScoreMin = value
+	}
+	if value, ok := pc.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+		_node.ScoreMax = value
+	}
+	if value, ok := pc.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+		_node.AllowVoteChanges = value
+	}
+	if value, ok := pc.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+		_node.Visibility = value
+	}
+	if value, ok := pc.mutation.ResultsPolicy(); ok {
+		_spec.SetField(poll.FieldResultsPolicy, field.TypeEnum, value)
+		_node.ResultsPolicy = value
+	}
 	if nodes := pc.mutation.CreatorIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -196,6 +608,54 @@ func (pc *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := pc.mutation.VoteHistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.InvitedUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.InvitedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -217,6 +677,7 @@ func (pcb *PollCreateBulk) Save(ctx context.Context) ([]*Poll, error) {
 	for i := range pcb.builders {
 		func(i int, root context.Context) {
 			builder := pcb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*PollMutation)
 				if !ok {
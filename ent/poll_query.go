@@ -7,11 +7,13 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"math"
+	"pollAppNew/ent/group"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/predicate"
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -22,13 +24,16 @@ import (
 // PollQuery is the builder for querying Poll entities.
 type PollQuery struct {
 	config
-	ctx         *QueryContext
-	order       []poll.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.Poll
-	withCreator *UserQuery
-	withOptions *PollOptionQuery
-	withVotes   *VoteQuery
+	ctx               *QueryContext
+	order             []poll.OrderOption
+	inters            []Interceptor
+	predicates        []predicate.Poll
+	withCreator       *UserQuery
+	withOptions       *PollOptionQuery
+	withVotes         *VoteQuery
+	withVoteHistory   *VoteHistoryQuery
+	withInvitedUsers  *UserQuery
+	withInvitedGroups *GroupQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -131,6 +136,72 @@ func (pq *PollQuery) QueryVotes() *VoteQuery {
 	return query
 }
 
+// QueryVoteHistory chains the current query on the "vote_history" edge.
+func (pq *PollQuery) QueryVoteHistory() *VoteHistoryQuery {
+	query := (&VoteHistoryClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(votehistory.Table, votehistory.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, poll.VoteHistoryTable, poll.VoteHistoryColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryInvitedUsers chains the current query on the "invited_users" edge.
+func (pq *PollQuery) QueryInvitedUsers() *UserQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, poll.InvitedUsersTable, poll.InvitedUsersPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryInvitedGroups chains the current query on the "invited_groups" edge.
+func (pq *PollQuery) QueryInvitedGroups() *GroupQuery {
+	query := (&GroupClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(group.Table, group.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, poll.InvitedGroupsTable, poll.InvitedGroupsPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Poll entity from the query.
 // Returns a *NotFoundError when no Poll was found.
 func (pq *PollQuery) First(ctx context.Context) (*Poll, error) {
@@ -318,14 +389,17 @@ func (pq *PollQuery) Clone() *PollQuery {
 		return nil
 	}
 	return &PollQuery{
-		config:      pq.config,
-		ctx:         pq.ctx.Clone(),
-		order:       append([]poll.OrderOption{}, pq.order...),
-		inters:      append([]Interceptor{}, pq.inters...),
-		predicates:  append([]predicate.Poll{}, pq.predicates...),
-		withCreator: pq.withCreator.Clone(),
-		withOptions: pq.withOptions.Clone(),
-		withVotes:   pq.withVotes.Clone(),
+		config:            pq.config,
+		ctx:               pq.ctx.Clone(),
+		order:             append([]poll.OrderOption{}, pq.order...),
+		inters:            append([]Interceptor{}, pq.inters...),
+		predicates:        append([]predicate.Poll{}, pq.predicates...),
+		withCreator:       pq.withCreator.Clone(),
+		withOptions:       pq.withOptions.Clone(),
+		withVotes:         pq.withVotes.Clone(),
+		withVoteHistory:   pq.withVoteHistory.Clone(),
+		withInvitedUsers:  pq.withInvitedUsers.Clone(),
+		withInvitedGroups: pq.withInvitedGroups.Clone(),
 		// clone intermediate query.
 		sql:  pq.sql.Clone(),
 		path: pq.path,
@@ -365,18 +439,51 @@ func (pq *PollQuery) WithVotes(opts ...func(*VoteQuery)) *PollQuery {
 	return pq
 }
 
+// WithVoteHistory tells the query-builder to eager-load the nodes that are connected to
+// the "vote_history" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithVoteHistory(opts ...func(*VoteHistoryQuery)) *PollQuery {
+	query := (&VoteHistoryClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withVoteHistory = query
+	return pq
+}
+
+// WithInvitedUsers tells the query-builder to eager-load the nodes that are connected to
+// the "invited_users" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithInvitedUsers(opts ...func(*UserQuery)) *PollQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withInvitedUsers = query
+	return pq
+}
+
+// WithInvitedGroups tells the query-builder to eager-load the nodes that are connected to
+// the "invited_groups" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithInvitedGroups(opts ...func(*GroupQuery)) *PollQuery {
+	query := (&GroupClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withInvitedGroups = query
+	return pq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
 // Example:
 //
 //	var v []struct {
-//		Title string `json:"title,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Poll.Query().
-//		GroupBy(poll.FieldTitle).
+//		GroupBy(poll.FieldCreatedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (pq *PollQuery) GroupBy(field string, fields ...string) *PollGroupBy {
@@ -394,11 +501,11 @@ func (pq *PollQuery) GroupBy(field string, fields ...string) *PollGroupBy {
 // Example:
 //
 //	var v []struct {
-//		Title string `json:"title,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //	}
 //
 //	client.Poll.Query().
-//		Select(poll.FieldTitle).
+//		Select(poll.FieldCreatedAt).
 //		Scan(ctx, &v)
 func (pq *PollQuery) Select(fields ...string) *PollSelect {
 	pq.ctx.Fields = append(pq.ctx.Fields, fields...)
@@ -443,10 +550,13 @@ func (pq *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 	var (
 		nodes       = []*Poll{}
 		_spec       = pq.querySpec()
-		loadedTypes = [3]bool{
+		loadedTypes = [6]bool{
 			pq.withCreator != nil,
 			pq.withOptions != nil,
 			pq.withVotes != nil,
+			pq.withVoteHistory != nil,
+			pq.withInvitedUsers != nil,
+			pq.withInvitedGroups != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -487,6 +597,27 @@ func (pq *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 			return nil, err
 		}
 	}
+	if query := pq.withVoteHistory; query != nil {
+		if err := pq.loadVoteHistory(ctx, query, nodes,
+			func(n *Poll) { n.Edges.VoteHistory = []*VoteHistory{} },
+			func(n *Poll, e *VoteHistory) { n.Edges.VoteHistory = append(n.Edges.VoteHistory, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := pq.withInvitedUsers; query != nil {
+		if err := pq.loadInvitedUsers(ctx, query, nodes,
+			func(n *Poll) { n.Edges.InvitedUsers = []*User{} },
+			func(n *Poll, e *User) { n.Edges.InvitedUsers = append(n.Edges.InvitedUsers, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := pq.withInvitedGroups; query != nil {
+		if err := pq.loadInvitedGroups(ctx, query, nodes,
+			func(n *Poll) { n.Edges.InvitedGroups = []*Group{} },
+			func(n *Poll, e *Group) { n.Edges.InvitedGroups = append(n.Edges.InvitedGroups, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -579,6 +710,158 @@ func (pq *PollQuery) loadVotes(ctx context.Context, query *VoteQuery, nodes []*P
 	}
 	return nil
 }
+func (pq *PollQuery) loadVoteHistory(ctx context.Context, query *VoteHistoryQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *VoteHistory)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(votehistory.FieldPollID)
+	}
+	query.Where(predicate.VoteHistory(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.VoteHistoryColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (pq *PollQuery) loadInvitedUsers(ctx context.Context, query *UserQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *User)) error {
+	edgeIDs := make([]driver.Value, len(nodes))
+	byID := make(map[int]*Poll)
+	nids := make(map[int]map[*Poll]struct{})
+	for i, node := range nodes {
+		edgeIDs[i] = node.ID
+		byID[node.ID] = node
+		if init != nil {
+			init(node)
+		}
+	}
+	query.Where(func(s *sql.Selector) {
+		joinT := sql.Table(poll.InvitedUsersTable)
+		s.Join(joinT).On(s.C(user.FieldID), joinT.C(poll.InvitedUsersPrimaryKey[1]))
+		s.Where(sql.InValues(joinT.C(poll.InvitedUsersPrimaryKey[0]), edgeIDs...))
+		columns := s.SelectedColumns()
+		s.Select(joinT.C(poll.InvitedUsersPrimaryKey[0]))
+		s.AppendSelect(columns...)
+		s.SetDistinct(false)
+	})
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]any, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]any{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []any) error {
+				outValue := int(values[0].(*sql.NullInt64).Int64)
+				inValue := int(values[1].(*sql.NullInt64).Int64)
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Poll]struct{}{byID[outValue]: {}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byID[outValue]] = struct{}{}
+				return nil
+			}
+		})
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected "invited_users" node returned %v`, n.ID)
+		}
+		for kn := range nodes {
+			assign(kn, n)
+		}
+	}
+	return nil
+}
+func (pq *PollQuery) loadInvitedGroups(ctx context.Context, query *GroupQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Group)) error {
+	edgeIDs := make([]driver.Value, len(nodes))
+	byID := make(map[int]*Poll)
+	nids := make(map[int]map[*Poll]struct{})
+	for i, node := range nodes {
+		edgeIDs[i] = node.ID
+		byID[node.ID] = node
+		if init != nil {
+			init(node)
+		}
+	}
+	query.Where(func(s *sql.Selector) {
+		joinT := sql.Table(poll.InvitedGroupsTable)
+		s.Join(joinT).On(s.C(group.FieldID), joinT.C(poll.InvitedGroupsPrimaryKey[1]))
+		s.Where(sql.InValues(joinT.C(poll.InvitedGroupsPrimaryKey[0]), edgeIDs...))
+		columns := s.SelectedColumns()
+		s.Select(joinT.C(poll.InvitedGroupsPrimaryKey[0]))
+		s.AppendSelect(columns...)
+		s.SetDistinct(false)
+	})
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]any, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]any{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []any) error {
+				outValue := int(values[0].(*sql.NullInt64).Int64)
+				inValue := int(values[1].(*sql.NullInt64).Int64)
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Poll]struct{}{byID[outValue]: {}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byID[outValue]] = struct{}{}
+				return nil
+			}
+		})
+	})
+	neighbors, err := withInterceptors[[]*Group](ctx, query, qr, query.inters)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected "invited_groups" node returned %v`, n.ID)
+		}
+		for kn := range nodes {
+			assign(kn, n)
+		}
+	}
+	return nil
+}
 
 func (pq *PollQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := pq.querySpec()
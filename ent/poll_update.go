@@ -6,11 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"pollAppNew/ent/group"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/predicate"
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -30,6 +33,12 @@ func (pu *PollUpdate) Where(ps ...predicate.Poll) *PollUpdate {
 	return pu
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (pu *PollUpdate) SetUpdatedAt(t time.Time) *PollUpdate {
+	pu.mutation.SetUpdatedAt(t)
+	return pu
+}
+
 // SetTitle sets the "title" field.
 func (pu *PollUpdate) SetTitle(s string) *PollUpdate {
 	pu.mutation.SetTitle(s)
@@ -58,6 +67,206 @@ func (pu *PollUpdate) SetNillableCreatorID(i *int) *PollUpdate {
 	return pu
 }
 
+// SetStatus sets the "status" field.
+func (pu *PollUpdate) SetStatus(po poll.Status) *PollUpdate {
+	pu.mutation.SetStatus(po)
+	return pu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableStatus(po *poll.Status) *PollUpdate {
+	if po != nil {
+		pu.SetStatus(*po)
+	}
+	return pu
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (pu *PollUpdate) SetOpensAt(t time.Time) *PollUpdate {
+	pu.mutation.SetOpensAt(t)
+	return pu
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableOpensAt(t *time.Time) *PollUpdate {
+	if t != nil {
+		pu.SetOpensAt(*t)
+	}
+	return pu
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (pu *PollUpdate) ClearOpensAt() *PollUpdate {
+	pu.mutation.ClearOpensAt()
+	return pu
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (pu *PollUpdate) SetClosesAt(t time.Time) *PollUpdate {
+	pu.mutation.SetClosesAt(t)
+	return pu
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableClosesAt(t *time.Time) *PollUpdate {
+	if t != nil {
+		pu.SetClosesAt(*t)
+	}
+	return pu
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (pu *PollUpdate) ClearClosesAt() *PollUpdate {
+	pu.mutation.ClearClosesAt()
+	return pu
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (pu *PollUpdate) SetClosedAt(t time.Time) *PollUpdate {
+	pu.mutation.SetClosedAt(t)
+	return pu
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableClosedAt(t *time.Time) *PollUpdate {
+	if t != nil {
+		pu.SetClosedAt(*t)
+	}
+	return pu
+}
+
+// ClearClosedAt clears the value of the "closed_at" field.
+func (pu *PollUpdate) ClearClosedAt() *PollUpdate {
+	pu.mutation.ClearClosedAt()
+	return pu
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (pu *PollUpdate) SetMinChoices(i int) *PollUpdate {
+	pu.mutation.ResetMinChoices()
+	pu.mutation.SetMinChoices(i)
+	return pu
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableMinChoices(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetMinChoices(*i)
+	}
+	return pu
+}
+
+// AddMinChoices adds i to the "min_choices" field.
+func (pu *PollUpdate) AddMinChoices(i int) *PollUpdate {
+	pu.mutation.AddMinChoices(i)
+	return pu
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (pu *PollUpdate) SetMaxChoices(i int) *PollUpdate {
+	pu.mutation.ResetMaxChoices()
+	pu.mutation.SetMaxChoices(i)
+	return pu
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableMaxChoices(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetMaxChoices(*i)
+	}
+	return pu
+}
+
+// AddMaxChoices adds i to the "max_choices" field.
+func (pu *PollUpdate) AddMaxChoices(i int) *PollUpdate {
+	pu.mutation.AddMaxChoices(i)
+	return pu
+}
+
+// SetScoreMin sets the "score_min" field.
+func (pu *PollUpdate) SetScoreMin(i int) *PollUpdate {
+	pu.mutation.ResetScoreMin()
+	pu.mutation.SetScoreMin(i)
+	return pu
+}
+
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableScoreMin(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetScoreMin(*i)
+	}
+	return pu
+}
+
+// AddScoreMin adds i to the "score_min" field.
+func (pu *PollUpdate) AddScoreMin(i int) *PollUpdate {
+	pu.mutation.AddScoreMin(i)
+	return pu
+}
+
+// SetScoreMax sets the "score_max" field.
+func (pu *PollUpdate) SetScoreMax(i int) *PollUpdate {
+	pu.mutation.ResetScoreMax()
+	pu.mutation.SetScoreMax(i)
+	return pu
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableScoreMax(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetScoreMax(*i)
+	}
+	return pu
+}
+
+// AddScoreMax adds i to the "score_max" field.
+func (pu *PollUpdate) AddScoreMax(i int) *PollUpdate {
+	pu.mutation.AddScoreMax(i)
+	return pu
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (pu *PollUpdate) SetAllowVoteChanges(b bool) *PollUpdate {
+	pu.mutation.SetAllowVoteChanges(b)
+	return pu
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableAllowVoteChanges(b *bool) *PollUpdate {
+	if b != nil {
+		pu.SetAllowVoteChanges(*b)
+	}
+	return pu
+}
+
+// SetVisibility sets the "visibility" field.
+func (pu *PollUpdate) SetVisibility(po poll.Visibility) *PollUpdate {
+	pu.mutation.SetVisibility(po)
+	return pu
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableVisibility(po *poll.Visibility) *PollUpdate {
+	if po != nil {
+		pu.SetVisibility(*po)
+	}
+	return pu
+}
+
+// SetResultsPolicy sets the "results_policy" field.
+func (pu *PollUpdate) SetResultsPolicy(pp poll.ResultsPolicy) *PollUpdate {
+	pu.mutation.SetResultsPolicy(pp)
+	return pu
+}
+
+// SetNillableResultsPolicy sets the "results_policy" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableResultsPolicy(pp *poll.ResultsPolicy) *PollUpdate {
+	if pp != nil {
+		pu.SetResultsPolicy(*pp)
+	}
+	return pu
+}
+
 // SetCreator sets the "creator" edge to the User entity.
 func (pu *PollUpdate) SetCreator(u *User) *PollUpdate {
 	return pu.SetCreatorID(u.ID)
@@ -93,6 +302,51 @@ func (pu *PollUpdate) AddVotes(v ...*Vote) *PollUpdate {
 	return pu.AddVoteIDs(ids...)
 }
 
+// AddVoteHistoryIDs adds the "vote_history" edge to the VoteHistory entity by IDs.
+func (pu *PollUpdate) AddVoteHistoryIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddVoteHistoryIDs(ids...)
+	return pu
+}
+
+// AddVoteHistory adds the "vote_history" edges to the VoteHistory entity.
+func (pu *PollUpdate) AddVoteHistory(v ...*VoteHistory) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return pu.AddVoteHistoryIDs(ids...)
+}
+
+// AddInvitedUserIDs adds the "invited_users" edge to the User entity by IDs.
+func (pu *PollUpdate) AddInvitedUserIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddInvitedUserIDs(ids...)
+	return pu
+}
+
+// AddInvitedUsers adds the "invited_users" edges to the User entity.
+func (pu *PollUpdate) AddInvitedUsers(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.AddInvitedUserIDs(ids...)
+}
+
+// AddInvitedGroupIDs adds the "invited_groups" edge to the Group entity by IDs.
+func (pu *PollUpdate) AddInvitedGroupIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddInvitedGroupIDs(ids...)
+	return pu
+}
+
+// AddInvitedGroups adds the "invited_groups" edges to the Group entity.
+func (pu *PollUpdate) AddInvitedGroups(g ...*Group) *PollUpdate {
+	ids := make([]int, len(g))
+	for i := range g {
+		ids[i] = g[i].ID
+	}
+	return pu.AddInvitedGroupIDs(ids...)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (pu *PollUpdate) Mutation() *PollMutation {
 	return pu.mutation
@@ -146,8 +400,72 @@ func (pu *PollUpdate) RemoveVotes(v ...*Vote) *PollUpdate {
 	return pu.RemoveVoteIDs(ids...)
 }
 
+// ClearVoteHistory clears all "vote_history" edges to the VoteHistory entity.
+func (pu *PollUpdate) ClearVoteHistory() *PollUpdate {
+	pu.mutation.ClearVoteHistory()
+	return pu
+}
+
+// RemoveVoteHistoryIDs removes the "vote_history" edge to VoteHistory entities by IDs.
+func (pu *PollUpdate) RemoveVoteHistoryIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveVoteHistoryIDs(ids...)
+	return pu
+}
+
+// RemoveVoteHistory removes "vote_history" edges to VoteHistory entities.
+func (pu *PollUpdate) RemoveVoteHistory(v ...*VoteHistory) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return pu.RemoveVoteHistoryIDs(ids...)
+}
+
+// ClearInvitedUsers clears all "invited_users" edges to the User entity.
+func (pu *PollUpdate) ClearInvitedUsers() *PollUpdate {
+	pu.mutation.ClearInvitedUsers()
+	return pu
+}
+
+// RemoveInvitedUserIDs removes the "invited_users" edge to User entities by IDs.
+func (pu *PollUpdate) RemoveInvitedUserIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveInvitedUserIDs(ids...)
+	return pu
+}
+
+// RemoveInvitedUsers removes "invited_users" edges to User entities.
+func (pu *PollUpdate) RemoveInvitedUsers(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.RemoveInvitedUserIDs(ids...)
+}
+
+// ClearInvitedGroups clears all "invited_groups" edges to the Group entity.
+func (pu *PollUpdate) ClearInvitedGroups() *PollUpdate {
+	pu.mutation.ClearInvitedGroups()
+	return pu
+}
+
+// RemoveInvitedGroupIDs removes the "invited_groups" edge to Group entities by IDs.
+func (pu *PollUpdate) RemoveInvitedGroupIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveInvitedGroupIDs(ids...)
+	return pu
+}
+
+// RemoveInvitedGroups removes "invited_groups" edges to Group entities.
+func (pu *PollUpdate) RemoveInvitedGroups(g ...*Group) *PollUpdate {
+	ids := make([]int, len(g))
+	for i := range g {
+		ids[i] = g[i].ID
+	}
+	return pu.RemoveInvitedGroupIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pu *PollUpdate) Save(ctx context.Context) (int, error) {
+	pu.defaults()
 	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
 }
 
@@ -173,6 +491,14 @@ func (pu *PollUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (pu *PollUpdate) defaults() {
+	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		v := poll.UpdateDefaultUpdatedAt()
+		pu.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (pu *PollUpdate) check() error {
 	if v, ok := pu.mutation.Title(); ok {
@@ -180,6 +506,31 @@ func (pu *PollUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := pu.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.ResultsPolicy(); ok {
+		if err := poll.ResultsPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "results_policy", err: fmt.Errorf(`ent: validator failed for field "Poll.results_policy": %w`, err)}
+		}
+	}
 	if pu.mutation.CreatorCleared() && len(pu.mutation.CreatorIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.creator"`)
 	}
@@ -198,9 +549,66 @@ func (pu *PollUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := pu.mutation.UpdatedAt(); ok {
+		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := pu.mutation.Title(); ok {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 	}
+	if value, ok := pu.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := pu.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if pu.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if pu.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+	}
+	if pu.mutation.ClosedAtCleared() {
+		_spec.ClearField(poll.FieldClosedAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedScoreMin(); ok {
+		_spec.AddField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedScoreMax(); ok {
+		_spec.AddField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+	}
+	if value, ok := pu.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+	}
+	if value, ok := pu.mutation.ResultsPolicy(); ok {
+		_spec.SetField(poll.FieldResultsPolicy, field.TypeEnum, value)
+	}
 	if pu.mutation.CreatorCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -320,50 +728,391 @@ func (pu *PollUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{poll.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
-		}
-		return 0, err
+	if pu.mutation.VoteHistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedVoteHistoryIDs(); len(nodes) > 0 && !pu.mutation.VoteHistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.VoteHistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.InvitedUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedInvitedUsersIDs(); len(nodes) > 0 && !pu.mutation.InvitedUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.InvitedUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.InvitedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedInvitedGroupsIDs(); len(nodes) > 0 && !pu.mutation.InvitedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.InvitedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{poll.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pu.mutation.done = true
+	return n, nil
+}
+
+// PollUpdateOne is the builder for updating a single Poll entity.
+type PollUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (puo *PollUpdateOne) SetUpdatedAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetUpdatedAt(t)
+	return puo
+}
+
+// SetTitle sets the "title" field.
+func (puo *PollUpdateOne) SetTitle(s string) *PollUpdateOne {
+	puo.mutation.SetTitle(s)
+	return puo
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableTitle(s *string) *PollUpdateOne {
+	if s != nil {
+		puo.SetTitle(*s)
+	}
+	return puo
+}
+
+// SetCreatorID sets the "creator_id" field.
+func (puo *PollUpdateOne) SetCreatorID(i int) *PollUpdateOne {
+	puo.mutation.SetCreatorID(i)
+	return puo
+}
+
+// SetNillableCreatorID sets the "creator_id" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableCreatorID(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetCreatorID(*i)
+	}
+	return puo
+}
+
+// SetStatus sets the "status" field.
+func (puo *PollUpdateOne) SetStatus(po poll.Status) *PollUpdateOne {
+	puo.mutation.SetStatus(po)
+	return puo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableStatus(po *poll.Status) *PollUpdateOne {
+	if po != nil {
+		puo.SetStatus(*po)
+	}
+	return puo
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (puo *PollUpdateOne) SetOpensAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetOpensAt(t)
+	return puo
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableOpensAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetOpensAt(*t)
+	}
+	return puo
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (puo *PollUpdateOne) ClearOpensAt() *PollUpdateOne {
+	puo.mutation.ClearOpensAt()
+	return puo
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (puo *PollUpdateOne) SetClosesAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetClosesAt(t)
+	return puo
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableClosesAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetClosesAt(*t)
+	}
+	return puo
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (puo *PollUpdateOne) ClearClosesAt() *PollUpdateOne {
+	puo.mutation.ClearClosesAt()
+	return puo
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (puo *PollUpdateOne) SetClosedAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetClosedAt(t)
+	return puo
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableClosedAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetClosedAt(*t)
+	}
+	return puo
+}
+
+// ClearClosedAt clears the value of the "closed_at" field.
+func (puo *PollUpdateOne) ClearClosedAt() *PollUpdateOne {
+	puo.mutation.ClearClosedAt()
+	return puo
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (puo *PollUpdateOne) SetMinChoices(i int) *PollUpdateOne {
+	puo.mutation.ResetMinChoices()
+	puo.mutation.SetMinChoices(i)
+	return puo
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableMinChoices(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetMinChoices(*i)
+	}
+	return puo
+}
+
+// AddMinChoices adds i to the "min_choices" field.
+func (puo *PollUpdateOne) AddMinChoices(i int) *PollUpdateOne {
+	puo.mutation.AddMinChoices(i)
+	return puo
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (puo *PollUpdateOne) SetMaxChoices(i int) *PollUpdateOne {
+	puo.mutation.ResetMaxChoices()
+	puo.mutation.SetMaxChoices(i)
+	return puo
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableMaxChoices(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetMaxChoices(*i)
 	}
-	pu.mutation.done = true
-	return n, nil
+	return puo
 }
 
-// PollUpdateOne is the builder for updating a single Poll entity.
-type PollUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *PollMutation
+// AddMaxChoices adds i to the "max_choices" field.
+func (puo *PollUpdateOne) AddMaxChoices(i int) *PollUpdateOne {
+	puo.mutation.AddMaxChoices(i)
+	return puo
 }
 
-// SetTitle sets the "title" field.
-func (puo *PollUpdateOne) SetTitle(s string) *PollUpdateOne {
-	puo.mutation.SetTitle(s)
+// SetScoreMin sets the "score_min" field.
+func (puo *PollUpdateOne) SetScoreMin(i int) *PollUpdateOne {
+	puo.mutation.ResetScoreMin()
+	puo.mutation.SetScoreMin(i)
 	return puo
 }
 
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (puo *PollUpdateOne) SetNillableTitle(s *string) *PollUpdateOne {
-	if s != nil {
-		puo.SetTitle(*s)
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableScoreMin(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetScoreMin(*i)
 	}
 	return puo
 }
 
-// SetCreatorID sets the "creator_id" field.
-func (puo *PollUpdateOne) SetCreatorID(i int) *PollUpdateOne {
-	puo.mutation.SetCreatorID(i)
+// AddScoreMin adds i to the "score_min" field.
+func (puo *PollUpdateOne) AddScoreMin(i int) *PollUpdateOne {
+	puo.mutation.AddScoreMin(i)
 	return puo
 }
 
-// SetNillableCreatorID sets the "creator_id" field if the given value is not nil.
-func (puo *PollUpdateOne) SetNillableCreatorID(i *int) *PollUpdateOne {
+// SetScoreMax sets the "score_max" field.
+func (puo *PollUpdateOne) SetScoreMax(i int) *PollUpdateOne {
+	puo.mutation.ResetScoreMax()
+	puo.mutation.SetScoreMax(i)
+	return puo
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableScoreMax(i *int) *PollUpdateOne {
 	if i != nil {
-		puo.SetCreatorID(*i)
+		puo.SetScoreMax(*i)
+	}
+	return puo
+}
+
+// AddScoreMax adds i to the "score_max" field.
+func (puo *PollUpdateOne) AddScoreMax(i int) *PollUpdateOne {
+	puo.mutation.AddScoreMax(i)
+	return puo
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (puo *PollUpdateOne) SetAllowVoteChanges(b bool) *PollUpdateOne {
+	puo.mutation.SetAllowVoteChanges(b)
+	return puo
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableAllowVoteChanges(b *bool) *PollUpdateOne {
+	if b != nil {
+		puo.SetAllowVoteChanges(*b)
+	}
+	return puo
+}
+
+// SetVisibility sets the "visibility" field.
+func (puo *PollUpdateOne) SetVisibility(po poll.Visibility) *PollUpdateOne {
+	puo.mutation.SetVisibility(po)
+	return puo
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableVisibility(po *poll.Visibility) *PollUpdateOne {
+	if po != nil {
+		puo.SetVisibility(*po)
+	}
+	return puo
+}
+
+// SetResultsPolicy sets the "results_policy" field.
+func (puo *PollUpdateOne) SetResultsPolicy(pp poll.ResultsPolicy) *PollUpdateOne {
+	puo.mutation.SetResultsPolicy(pp)
+	return puo
+}
+
+// SetNillableResultsPolicy sets the "results_policy" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableResultsPolicy(pp *poll.ResultsPolicy) *PollUpdateOne {
+	if pp != nil {
+		puo.SetResultsPolicy(*pp)
 	}
 	return puo
 }
@@ -403,6 +1152,51 @@ func (puo *PollUpdateOne) AddVotes(v ...*Vote) *PollUpdateOne {
 	return puo.AddVoteIDs(ids...)
 }
 
+// AddVoteHistoryIDs adds the "vote_history" edge to the VoteHistory entity by IDs.
+func (puo *PollUpdateOne) AddVoteHistoryIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddVoteHistoryIDs(ids...)
+	return puo
+}
+
+// AddVoteHistory adds the "vote_history" edges to the VoteHistory entity.
+func (puo *PollUpdateOne) AddVoteHistory(v ...*VoteHistory) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return puo.AddVoteHistoryIDs(ids...)
+}
+
+// AddInvitedUserIDs adds the "invited_users" edge to the User entity by IDs.
+func (puo *PollUpdateOne) AddInvitedUserIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddInvitedUserIDs(ids...)
+	return puo
+}
+
+// AddInvitedUsers adds the "invited_users" edges to the User entity.
+func (puo *PollUpdateOne) AddInvitedUsers(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.AddInvitedUserIDs(ids...)
+}
+
+// AddInvitedGroupIDs adds the "invited_groups" edge to the Group entity by IDs.
+func (puo *PollUpdateOne) AddInvitedGroupIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddInvitedGroupIDs(ids...)
+	return puo
+}
+
+// AddInvitedGroups adds the "invited_groups" edges to the Group entity.
+func (puo *PollUpdateOne) AddInvitedGroups(g ...*Group) *PollUpdateOne {
+	ids := make([]int, len(g))
+	for i := range g {
+		ids[i] = g[i].ID
+	}
+	return puo.AddInvitedGroupIDs(ids...)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (puo *PollUpdateOne) Mutation() *PollMutation {
 	return puo.mutation
@@ -456,6 +1250,69 @@ func (puo *PollUpdateOne) RemoveVotes(v ...*Vote) *PollUpdateOne {
 	return puo.RemoveVoteIDs(ids...)
 }
 
+// ClearVoteHistory clears all "vote_history" edges to the VoteHistory entity.
+func (puo *PollUpdateOne) ClearVoteHistory() *PollUpdateOne {
+	puo.mutation.ClearVoteHistory()
+	return puo
+}
+
+// RemoveVoteHistoryIDs removes the "vote_history" edge to VoteHistory entities by IDs.
+func (puo *PollUpdateOne) RemoveVoteHistoryIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveVoteHistoryIDs(ids...)
+	return puo
+}
+
+// RemoveVoteHistory removes "vote_history" edges to VoteHistory entities.
+func (puo *PollUpdateOne) RemoveVoteHistory(v ...*VoteHistory) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return puo.RemoveVoteHistoryIDs(ids...)
+}
+
+// ClearInvitedUsers clears all "invited_users" edges to the User entity.
+func (puo *PollUpdateOne) ClearInvitedUsers() *PollUpdateOne {
+	puo.mutation.ClearInvitedUsers()
+	return puo
+}
+
+// RemoveInvitedUserIDs removes the "invited_users" edge to User entities by IDs.
+func (puo *PollUpdateOne) RemoveInvitedUserIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveInvitedUserIDs(ids...)
+	return puo
+}
+
+// RemoveInvitedUsers removes "invited_users" edges to User entities.
+func (puo *PollUpdateOne) RemoveInvitedUsers(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.RemoveInvitedUserIDs(ids...)
+}
+
+// ClearInvitedGroups clears all "invited_groups" edges to the Group entity.
+func (puo *PollUpdateOne) ClearInvitedGroups() *PollUpdateOne {
+	puo.mutation.ClearInvitedGroups()
+	return puo
+}
+
+// RemoveInvitedGroupIDs removes the "invited_groups" edge to Group entities by IDs.
+func (puo *PollUpdateOne) RemoveInvitedGroupIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveInvitedGroupIDs(ids...)
+	return puo
+}
+
+// RemoveInvitedGroups removes "invited_groups" edges to Group entities.
+func (puo *PollUpdateOne) RemoveInvitedGroups(g ...*Group) *PollUpdateOne {
+	ids := make([]int, len(g))
+	for i := range g {
+		ids[i] = g[i].ID
+	}
+	return puo.RemoveInvitedGroupIDs(ids...)
+}
+
 // Where appends a list predicates to the PollUpdate builder.
 func (puo *PollUpdateOne) Where(ps ...predicate.Poll) *PollUpdateOne {
 	puo.mutation.Where(ps...)
@@ -471,6 +1328,7 @@ func (puo *PollUpdateOne) Select(field string, fields ...string) *PollUpdateOne
 
 // Save executes the query and returns the updated Poll entity.
 func (puo *PollUpdateOne) Save(ctx context.Context) (*Poll, error) {
+	puo.defaults()
 	return withHooks(ctx, puo.sqlSave, puo.mutation, puo.hooks)
 }
 
@@ -496,6 +1354,14 @@ func (puo *PollUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (puo *PollUpdateOne) defaults() {
+	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		v := poll.UpdateDefaultUpdatedAt()
+		puo.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (puo *PollUpdateOne) check() error {
 	if v, ok := puo.mutation.Title(); ok {
@@ -503,6 +1369,31 @@ func (puo *PollUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := puo.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.ResultsPolicy(); ok {
+		if err := poll.ResultsPolicyValidator(v); err != nil {
+			return &ValidationError{Name: "results_policy", err: fmt.Errorf(`ent: validator failed for field "Poll.results_policy": %w`, err)}
+		}
+	}
 	if puo.mutation.CreatorCleared() && len(puo.mutation.CreatorIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.creator"`)
 	}
@@ -538,9 +1429,66 @@ func (puo *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error)
 			}
 		}
 	}
+	if value, ok := puo.mutation.UpdatedAt(); ok {
+		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := puo.mutation.Title(); ok {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 	}
+	if value, ok := puo.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := puo.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if puo.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if puo.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+	}
+	if puo.mutation.ClosedAtCleared() {
+		_spec.ClearField(poll.FieldClosedAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedScoreMin(); ok {
+		_spec.AddField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedScoreMax(); ok {
+		_spec.AddField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+	}
+	if value, ok := puo.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+	}
+	if value, ok := puo.mutation.ResultsPolicy(); ok {
+		_spec.SetField(poll.FieldResultsPolicy, field.TypeEnum, value)
+	}
 	if puo.mutation.CreatorCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -660,6 +1608,141 @@ func (puo *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if puo.mutation.VoteHistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedVoteHistoryIDs(); len(nodes) > 0 && !puo.mutation.VoteHistoryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.VoteHistoryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.VoteHistoryTable,
+			Columns: []string{poll.VoteHistoryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.InvitedUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedInvitedUsersIDs(); len(nodes) > 0 && !puo.mutation.InvitedUsersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.InvitedUsersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedUsersTable,
+			Columns: poll.InvitedUsersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.InvitedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedInvitedGroupsIDs(); len(nodes) > 0 && !puo.mutation.InvitedGroupsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.InvitedGroupsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InvitedGroupsTable,
+			Columns: poll.InvitedGroupsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(group.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Poll{config: puo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -9,6 +9,7 @@ import (
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/vote"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -21,6 +22,34 @@ type PollOptionCreate struct {
 	hooks    []Hook
 }
 
+// SetCreatedAt sets the "created_at" field.
+func (poc *PollOptionCreate) SetCreatedAt(t time.Time) *PollOptionCreate {
+	poc.mutation.SetCreatedAt(t)
+	return poc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (poc *PollOptionCreate) SetNillableCreatedAt(t *time.Time) *PollOptionCreate {
+	if t != nil {
+		poc.SetCreatedAt(*t)
+	}
+	return poc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (poc *PollOptionCreate) SetUpdatedAt(t time.Time) *PollOptionCreate {
+	poc.mutation.SetUpdatedAt(t)
+	return poc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (poc *PollOptionCreate) SetNillableUpdatedAt(t *time.Time) *PollOptionCreate {
+	if t != nil {
+		poc.SetUpdatedAt(*t)
+	}
+	return poc
+}
+
 // SetText sets the "text" field.
 func (poc *PollOptionCreate) SetText(s string) *PollOptionCreate {
 	poc.mutation.SetText(s)
@@ -60,6 +89,7 @@ func (poc *PollOptionCreate) Mutation() *PollOptionMutation {
 
 // Save creates the PollOption in the database.
 func (poc *PollOptionCreate) Save(ctx context.Context) (*PollOption, error) {
+	poc.defaults()
 	return withHooks(ctx, poc.sqlSave, poc.mutation, poc.hooks)
 }
 
@@ -85,8 +115,26 @@ func (poc *PollOptionCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (poc *PollOptionCreate) defaults() {
+	if _, ok := poc.mutation.CreatedAt(); !ok {
+		v := polloption.DefaultCreatedAt()
+		poc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := poc.mutation.UpdatedAt(); !ok {
+		v := polloption.DefaultUpdatedAt()
+		poc.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (poc *PollOptionCreate) check() error {
+	if _, ok := poc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PollOption.created_at"`)}
+	}
+	if _, ok := poc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "PollOption.updated_at"`)}
+	}
 	if _, ok := poc.mutation.Text(); !ok {
 		return &ValidationError{Name: "text", err: errors.New(`ent: missing required field "PollOption.text"`)}
 	}
@@ -127,6 +175,14 @@ func (poc *PollOptionCreate) createSpec() (*PollOption, *sqlgraph.CreateSpec) {
 		_node = &PollOption{config: poc.config}
 		_spec = sqlgraph.NewCreateSpec(polloption.Table, sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeInt))
 	)
+	if value, ok := poc.mutation.CreatedAt(); ok {
+		_spec.SetField(polloption.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := poc.mutation.UpdatedAt(); ok {
+		_spec.SetField(polloption.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
 	if value, ok := poc.mutation.Text(); ok {
 		_spec.SetField(polloption.FieldText, field.TypeString, value)
 		_node.Text = value
@@ -185,6 +241,7 @@ func (pocb *PollOptionCreateBulk) Save(ctx context.Context) ([]*PollOption, erro
 	for i := range pocb.builders {
 		func(i int, root context.Context) {
 			builder := pocb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*PollOptionMutation)
 				if !ok {
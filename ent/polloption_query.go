@@ -335,12 +335,12 @@ func (poq *PollOptionQuery) WithVotes(opts ...func(*VoteQuery)) *PollOptionQuery
 // Example:
 //
 //	var v []struct {
-//		Text string `json:"text,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.PollOption.Query().
-//		GroupBy(polloption.FieldText).
+//		GroupBy(polloption.FieldCreatedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (poq *PollOptionQuery) GroupBy(field string, fields ...string) *PollOptionGroupBy {
@@ -358,11 +358,11 @@ func (poq *PollOptionQuery) GroupBy(field string, fields ...string) *PollOptionG
 // Example:
 //
 //	var v []struct {
-//		Text string `json:"text,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //	}
 //
 //	client.PollOption.Query().
-//		Select(polloption.FieldText).
+//		Select(polloption.FieldCreatedAt).
 //		Scan(ctx, &v)
 func (poq *PollOptionQuery) Select(fields ...string) *PollOptionSelect {
 	poq.ctx.Fields = append(poq.ctx.Fields, fields...)
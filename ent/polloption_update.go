@@ -10,6 +10,7 @@ import (
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/predicate"
 	"pollAppNew/ent/vote"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -29,6 +30,12 @@ func (pou *PollOptionUpdate) Where(ps ...predicate.PollOption) *PollOptionUpdate
 	return pou
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (pou *PollOptionUpdate) SetUpdatedAt(t time.Time) *PollOptionUpdate {
+	pou.mutation.SetUpdatedAt(t)
+	return pou
+}
+
 // SetText sets the "text" field.
 func (pou *PollOptionUpdate) SetText(s string) *PollOptionUpdate {
 	pou.mutation.SetText(s)
@@ -111,6 +118,7 @@ func (pou *PollOptionUpdate) RemoveVotes(v ...*Vote) *PollOptionUpdate {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pou *PollOptionUpdate) Save(ctx context.Context) (int, error) {
+	pou.defaults()
 	return withHooks(ctx, pou.sqlSave, pou.mutation, pou.hooks)
 }
 
@@ -136,6 +144,14 @@ func (pou *PollOptionUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (pou *PollOptionUpdate) defaults() {
+	if _, ok := pou.mutation.UpdatedAt(); !ok {
+		v := polloption.UpdateDefaultUpdatedAt()
+		pou.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (pou *PollOptionUpdate) check() error {
 	if v, ok := pou.mutation.Text(); ok {
@@ -161,6 +177,9 @@ func (pou *PollOptionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := pou.mutation.UpdatedAt(); ok {
+		_spec.SetField(polloption.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := pou.mutation.Text(); ok {
 		_spec.SetField(polloption.FieldText, field.TypeString, value)
 	}
@@ -258,6 +277,12 @@ type PollOptionUpdateOne struct {
 	mutation *PollOptionMutation
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (pouo *PollOptionUpdateOne) SetUpdatedAt(t time.Time) *PollOptionUpdateOne {
+	pouo.mutation.SetUpdatedAt(t)
+	return pouo
+}
+
 // SetText sets the "text" field.
 func (pouo *PollOptionUpdateOne) SetText(s string) *PollOptionUpdateOne {
 	pouo.mutation.SetText(s)
@@ -353,6 +378,7 @@ func (pouo *PollOptionUpdateOne) Select(field string, fields ...string) *PollOpt
 
 // Save executes the query and returns the updated PollOption entity.
 func (pouo *PollOptionUpdateOne) Save(ctx context.Context) (*PollOption, error) {
+	pouo.defaults()
 	return withHooks(ctx, pouo.sqlSave, pouo.mutation, pouo.hooks)
 }
 
@@ -378,6 +404,14 @@ func (pouo *PollOptionUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (pouo *PollOptionUpdateOne) defaults() {
+	if _, ok := pouo.mutation.UpdatedAt(); !ok {
+		v := polloption.UpdateDefaultUpdatedAt()
+		pouo.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (pouo *PollOptionUpdateOne) check() error {
 	if v, ok := pouo.mutation.Text(); ok {
@@ -420,6 +454,9 @@ func (pouo *PollOptionUpdateOne) sqlSave(ctx context.Context) (_node *PollOption
 			}
 		}
 	}
+	if value, ok := pouo.mutation.UpdatedAt(); ok {
+		_spec.SetField(polloption.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := pouo.mutation.Text(); ok {
 		_spec.SetField(polloption.FieldText, field.TypeString, value)
 	}
@@ -6,14 +6,35 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// AccessToken is the predicate function for accesstoken builders.
+type AccessToken func(*sql.Selector)
+
+// Group is the predicate function for group builders.
+type Group func(*sql.Selector)
+
+// Identity is the predicate function for identity builders.
+type Identity func(*sql.Selector)
+
 // Poll is the predicate function for poll builders.
 type Poll func(*sql.Selector)
 
 // PollOption is the predicate function for polloption builders.
 type PollOption func(*sql.Selector)
 
+// RecoveryCode is the predicate function for recoverycode builders.
+type RecoveryCode func(*sql.Selector)
+
+// Session is the predicate function for session builders.
+type Session func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
 
+// UserToken is the predicate function for usertoken builders.
+type UserToken func(*sql.Selector)
+
 // Vote is the predicate function for vote builders.
 type Vote func(*sql.Selector)
+
+// VoteHistory is the predicate function for votehistory builders.
+type VoteHistory func(*sql.Selector)
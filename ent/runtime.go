@@ -3,36 +3,221 @@
 package ent
 
 import (
+	"pollAppNew/ent/accesstoken"
+	"pollAppNew/ent/group"
+	"pollAppNew/ent/identity"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
+	"pollAppNew/ent/recoverycode"
 	"pollAppNew/ent/schema"
+	"pollAppNew/ent/session"
 	"pollAppNew/ent/user"
+	"pollAppNew/ent/usertoken"
+	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+	"time"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	accesstokenFields := schema.AccessToken{}.Fields()
+	_ = accesstokenFields
+	// accesstokenDescName is the schema descriptor for name field.
+	accesstokenDescName := accesstokenFields[0].Descriptor()
+	// accesstoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	accesstoken.NameValidator = accesstokenDescName.Validators[0].(func(string) error)
+	// accesstokenDescTokenHash is the schema descriptor for token_hash field.
+	accesstokenDescTokenHash := accesstokenFields[1].Descriptor()
+	// accesstoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	accesstoken.TokenHashValidator = accesstokenDescTokenHash.Validators[0].(func(string) error)
+	// accesstokenDescCreatedAt is the schema descriptor for created_at field.
+	accesstokenDescCreatedAt := accesstokenFields[5].Descriptor()
+	// accesstoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	accesstoken.DefaultCreatedAt = accesstokenDescCreatedAt.Default.(func() time.Time)
+	groupFields := schema.Group{}.Fields()
+	_ = groupFields
+	// groupDescDisplayName is the schema descriptor for display_name field.
+	groupDescDisplayName := groupFields[0].Descriptor()
+	// group.DisplayNameValidator is a validator for the "display_name" field. It is called by the builders before save.
+	group.DisplayNameValidator = groupDescDisplayName.Validators[0].(func(string) error)
+	// groupDescCreatedAt is the schema descriptor for created_at field.
+	groupDescCreatedAt := groupFields[2].Descriptor()
+	// group.DefaultCreatedAt holds the default value on creation for the created_at field.
+	group.DefaultCreatedAt = groupDescCreatedAt.Default.(func() time.Time)
+	identityFields := schema.Identity{}.Fields()
+	_ = identityFields
+	// identityDescProvider is the schema descriptor for provider field.
+	identityDescProvider := identityFields[0].Descriptor()
+	// identity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	identity.ProviderValidator = identityDescProvider.Validators[0].(func(string) error)
+	// identityDescSubject is the schema descriptor for subject field.
+	identityDescSubject := identityFields[1].Descriptor()
+	// identity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	identity.SubjectValidator = identityDescSubject.Validators[0].(func(string) error)
+	// identityDescCreatedAt is the schema descriptor for created_at field.
+	identityDescCreatedAt := identityFields[4].Descriptor()
+	// identity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	identity.DefaultCreatedAt = identityDescCreatedAt.Default.(func() time.Time)
+	pollMixin := schema.Poll{}.Mixin()
+	pollMixinFields0 := pollMixin[0].Fields()
+	_ = pollMixinFields0
 	pollFields := schema.Poll{}.Fields()
 	_ = pollFields
+	// pollDescCreatedAt is the schema descriptor for created_at field.
+	pollDescCreatedAt := pollMixinFields0[0].Descriptor()
+	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
+	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
+	// pollDescUpdatedAt is the schema descriptor for updated_at field.
+	pollDescUpdatedAt := pollMixinFields0[1].Descriptor()
+	// poll.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	poll.DefaultUpdatedAt = pollDescUpdatedAt.Default.(func() time.Time)
+	// poll.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	poll.UpdateDefaultUpdatedAt = pollDescUpdatedAt.UpdateDefault.(func() time.Time)
 	// pollDescTitle is the schema descriptor for title field.
 	pollDescTitle := pollFields[0].Descriptor()
 	// poll.TitleValidator is a validator for the "title" field. It is called by the builders before save.
 	poll.TitleValidator = pollDescTitle.Validators[0].(func(string) error)
+	// pollDescMinChoices is the schema descriptor for min_choices field.
+	pollDescMinChoices := pollFields[7].Descriptor()
+	// poll.DefaultMinChoices holds the default value on creation for the min_choices field.
+	poll.DefaultMinChoices = pollDescMinChoices.Default.(int)
+	// poll.MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	poll.MinChoicesValidator = pollDescMinChoices.Validators[0].(func(int) error)
+	// pollDescMaxChoices is the schema descriptor for max_choices field.
+	pollDescMaxChoices := pollFields[8].Descriptor()
+	// poll.DefaultMaxChoices holds the default value on creation for the max_choices field.
+	poll.DefaultMaxChoices = pollDescMaxChoices.Default.(int)
+	// poll.MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	poll.MaxChoicesValidator = pollDescMaxChoices.Validators[0].(func(int) error)
+	// pollDescScoreMin is the schema descriptor for score_min field.
+	pollDescScoreMin := pollFields[9].Descriptor()
+	// poll.DefaultScoreMin holds the default value on creation for the score_min field.
+	poll.DefaultScoreMin = pollDescScoreMin.Default.(int)
+	// pollDescScoreMax is the schema descriptor for score_max field.
+	pollDescScoreMax := pollFields[10].Descriptor()
+	// poll.DefaultScoreMax holds the default value on creation for the score_max field.
+	poll.DefaultScoreMax = pollDescScoreMax.Default.(int)
+	// pollDescAllowVoteChanges is the schema descriptor for allow_vote_changes field.
+	pollDescAllowVoteChanges := pollFields[11].Descriptor()
+	// poll.DefaultAllowVoteChanges holds the default value on creation for the allow_vote_changes field.
+	poll.DefaultAllowVoteChanges = pollDescAllowVoteChanges.Default.(bool)
+	polloptionMixin := schema.PollOption{}.Mixin()
+	polloptionMixinFields0 := polloptionMixin[0].Fields()
+	_ = polloptionMixinFields0
 	polloptionFields := schema.PollOption{}.Fields()
 	_ = polloptionFields
+	// polloptionDescCreatedAt is the schema descriptor for created_at field.
+	polloptionDescCreatedAt := polloptionMixinFields0[0].Descriptor()
+	// polloption.DefaultCreatedAt holds the default value on creation for the created_at field.
+	polloption.DefaultCreatedAt = polloptionDescCreatedAt.Default.(func() time.Time)
+	// polloptionDescUpdatedAt is the schema descriptor for updated_at field.
+	polloptionDescUpdatedAt := polloptionMixinFields0[1].Descriptor()
+	// polloption.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	polloption.DefaultUpdatedAt = polloptionDescUpdatedAt.Default.(func() time.Time)
+	// polloption.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	polloption.UpdateDefaultUpdatedAt = polloptionDescUpdatedAt.UpdateDefault.(func() time.Time)
 	// polloptionDescText is the schema descriptor for text field.
 	polloptionDescText := polloptionFields[0].Descriptor()
 	// polloption.TextValidator is a validator for the "text" field. It is called by the builders before save.
 	polloption.TextValidator = polloptionDescText.Validators[0].(func(string) error)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[0].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[2].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescTokenHash is the schema descriptor for token_hash field.
+	sessionDescTokenHash := sessionFields[0].Descriptor()
+	// session.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	session.TokenHashValidator = sessionDescTokenHash.Validators[0].(func(string) error)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[5].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescLastSeenAt is the schema descriptor for last_seen_at field.
+	sessionDescLastSeenAt := sessionFields[6].Descriptor()
+	// session.DefaultLastSeenAt holds the default value on creation for the last_seen_at field.
+	session.DefaultLastSeenAt = sessionDescLastSeenAt.Default.(func() time.Time)
+	// sessionDescAttempts is the schema descriptor for attempts field.
+	sessionDescAttempts := sessionFields[8].Descriptor()
+	// session.DefaultAttempts holds the default value on creation for the attempts field.
+	session.DefaultAttempts = sessionDescAttempts.Default.(int)
+	userMixin := schema.User{}.Mixin()
+	userMixinFields0 := userMixin[0].Fields()
+	_ = userMixinFields0
 	userFields := schema.User{}.Fields()
 	_ = userFields
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userMixinFields0[0].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userMixinFields0[1].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
 	// userDescUsername is the schema descriptor for username field.
 	userDescUsername := userFields[0].Descriptor()
 	// user.UsernameValidator is a validator for the "username" field. It is called by the builders before save.
 	user.UsernameValidator = userDescUsername.Validators[0].(func(string) error)
-	// userDescPasswordHash is the schema descriptor for password_hash field.
-	userDescPasswordHash := userFields[1].Descriptor()
-	// user.PasswordHashValidator is a validator for the "password_hash" field. It is called by the builders before save.
-	user.PasswordHashValidator = userDescPasswordHash.Validators[0].(func(string) error)
+	// userDescEmailVerified is the schema descriptor for email_verified field.
+	userDescEmailVerified := userFields[3].Descriptor()
+	// user.DefaultEmailVerified holds the default value on creation for the email_verified field.
+	user.DefaultEmailVerified = userDescEmailVerified.Default.(bool)
+	// userDescTotpEnabled is the schema descriptor for totp_enabled field.
+	userDescTotpEnabled := userFields[5].Descriptor()
+	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
+	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
+	// userDescTotpLastStep is the schema descriptor for totp_last_step field.
+	userDescTotpLastStep := userFields[6].Descriptor()
+	// user.DefaultTotpLastStep holds the default value on creation for the totp_last_step field.
+	user.DefaultTotpLastStep = userDescTotpLastStep.Default.(int64)
+	// userDescActive is the schema descriptor for active field.
+	userDescActive := userFields[10].Descriptor()
+	// user.DefaultActive holds the default value on creation for the active field.
+	user.DefaultActive = userDescActive.Default.(bool)
+	usertokenFields := schema.UserToken{}.Fields()
+	_ = usertokenFields
+	// usertokenDescTokenHash is the schema descriptor for token_hash field.
+	usertokenDescTokenHash := usertokenFields[1].Descriptor()
+	// usertoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	usertoken.TokenHashValidator = usertokenDescTokenHash.Validators[0].(func(string) error)
+	// usertokenDescCreatedAt is the schema descriptor for created_at field.
+	usertokenDescCreatedAt := usertokenFields[4].Descriptor()
+	// usertoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	usertoken.DefaultCreatedAt = usertokenDescCreatedAt.Default.(func() time.Time)
+	voteMixin := schema.Vote{}.Mixin()
+	voteMixinFields0 := voteMixin[0].Fields()
+	_ = voteMixinFields0
+	voteFields := schema.Vote{}.Fields()
+	_ = voteFields
+	// voteDescCreatedAt is the schema descriptor for created_at field.
+	voteDescCreatedAt := voteMixinFields0[0].Descriptor()
+	// vote.DefaultCreatedAt holds the default value on creation for the created_at field.
+	vote.DefaultCreatedAt = voteDescCreatedAt.Default.(func() time.Time)
+	// voteDescUpdatedAt is the schema descriptor for updated_at field.
+	voteDescUpdatedAt := voteMixinFields0[1].Descriptor()
+	// vote.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	vote.DefaultUpdatedAt = voteDescUpdatedAt.Default.(func() time.Time)
+	// vote.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	vote.UpdateDefaultUpdatedAt = voteDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// voteDescPosition is the schema descriptor for position field.
+	voteDescPosition := voteFields[3].Descriptor()
+	// vote.DefaultPosition holds the default value on creation for the position field.
+	vote.DefaultPosition = voteDescPosition.Default.(int)
+	votehistoryFields := schema.VoteHistory{}.Fields()
+	_ = votehistoryFields
+	// votehistoryDescCreatedAt is the schema descriptor for created_at field.
+	votehistoryDescCreatedAt := votehistoryFields[5].Descriptor()
+	// votehistory.DefaultCreatedAt holds the default value on creation for the created_at field.
+	votehistory.DefaultCreatedAt = votehistoryDescCreatedAt.Default.(func() time.Time)
 }
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// AccessToken holds the schema definition for a named personal access token
+// used by automation. Like sessions, only a hash of the token is stored.
+type AccessToken struct {
+	ent.Schema
+}
+
+// Fields of the AccessToken.
+func (AccessToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").NotEmpty(),
+		field.String("token_hash").Unique().NotEmpty().Sensitive(),
+		// prefix is the first characters of the token, shown so users can tell tokens apart.
+		field.String("prefix"),
+		field.Strings("scopes"),
+		field.Int("user_id"),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("expires_at").Optional().Nillable(),
+		field.Time("last_used_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the AccessToken.
+func (AccessToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("access_tokens").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Group is a named set of users, maintained by a provisioning client over SCIM.
+type Group struct {
+	ent.Schema
+}
+
+// Fields of the Group.
+func (Group) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("display_name").Unique().NotEmpty(),
+		// external_id is the provisioning client's identifier for the group.
+		field.String("external_id").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the Group.
+func (Group) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("members", User.Type),
+		edge.From("invited_polls", Poll.Type).
+			Ref("invited_groups"),
+	}
+}
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
@@ -11,11 +12,58 @@ type Poll struct {
 	ent.Schema
 }
 
+// Mixin of the Poll.
+func (Poll) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
 // Fields of the Poll.
 func (Poll) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("title").NotEmpty(),
 		field.Int("creator_id"),
+		// status moves draft -> scheduled -> open -> closed -> archived;
+		// internal/lifecycle owns the transitions.
+		field.Enum("status").
+			Values("draft", "scheduled", "open", "closed", "archived").
+			Default("open"),
+		// opens_at and closes_at bound the voting window when set.
+		field.Time("opens_at").Optional().Nillable(),
+		field.Time("closes_at").Optional().Nillable(),
+		// closed_at is set once the poll stops accepting votes.
+		field.Time("closed_at").Optional().Nillable(),
+		// method decides how ballots are cast and counted: plurality
+		// ballots select options, irv and schulze ballots rank them, and
+		// score and star ballots rate every option.
+		field.Enum("method").
+			Values("plurality", "irv", "schulze", "score", "star").
+			Default("plurality").
+			Immutable(),
+		// min_choices and max_choices bound how many options a ballot
+		// selects; both are 1 for single-choice polls.
+		field.Int("min_choices").Default(1).Min(1),
+		field.Int("max_choices").Default(1).Min(1),
+		// score_min and score_max are the rating scale of score and star
+		// polls.
+		field.Int("score_min").Default(0),
+		field.Int("score_max").Default(5),
+		// allow_vote_changes lets voters change or retract their ballot
+		// while the poll is open.
+		field.Bool("allow_vote_changes").Default(false),
+		// visibility decides who can find the poll: public polls are
+		// listed for everyone, unlisted ones reached only by link, and
+		// private ones seen only by invited users and groups.
+		field.Enum("visibility").
+			Values("public", "unlisted", "private").
+			Default("public"),
+		// results_policy decides when counts are shown to those who can
+		// see the poll: always, once they voted, once the poll closed, or
+		// only to its owner. Its managers always see them.
+		field.Enum("results_policy").
+			Values("always", "after_vote", "after_close", "owner_only").
+			Default("always"),
 	}
 }
 
@@ -29,5 +77,9 @@ func (Poll) Edges() []ent.Edge {
 			Required(),
 		edge.To("options", PollOption.Type),
 		edge.To("votes", Vote.Type),
+		edge.To("vote_history", VoteHistory.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("invited_users", User.Type),
+		edge.To("invited_groups", Group.Type),
 	}
 }
@@ -11,6 +11,13 @@ type PollOption struct {
 	ent.Schema
 }
 
+// Mixin of the PollOption.
+func (PollOption) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
 // Fields of the PollOption.
 func (PollOption) Fields() []ent.Field {
 	return []ent.Field{
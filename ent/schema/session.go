@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Session holds the schema definition for a server-side login session.
+// Only a SHA-256 hash of the opaque token handed to the client is stored.
+// Browser logins get a "cookie" session; API clients get a "refresh" session
+// whose token is exchanged at /auth/token for short-lived access tokens.
+// An "mfa" session is a short-lived challenge left by a correct password on
+// an account with two-factor authentication; it authenticates nothing until
+// redeemed with a second factor.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_hash").Unique().NotEmpty().Sensitive(),
+		field.Int("user_id"),
+		field.Enum("kind").Values("cookie", "refresh", "mfa").Default("cookie"),
+		field.String("user_agent").Optional(),
+		field.String("ip").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("last_seen_at").Default(time.Now),
+		field.Time("expires_at"),
+		// attempts counts wrong second-factor codes entered against an mfa session.
+		field.Int("attempts").Default(0),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
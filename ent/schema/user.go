@@ -2,6 +2,7 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
@@ -11,11 +12,38 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("username").Unique().NotEmpty(),
-		field.String("password_hash").NotEmpty(),
+		// password_hash is empty for users who only sign in through SSO.
+		field.String("password_hash").Optional().Sensitive(),
+		field.String("email").Optional().Nillable().Unique(),
+		field.Bool("email_verified").Default(false),
+		// totp_secret is set at enrollment but only trusted once totp_enabled.
+		field.String("totp_secret").Optional().Sensitive(),
+		field.Bool("totp_enabled").Default(false),
+		// totp_last_step is the time step of the last accepted code, so a
+		// code can't be replayed within its validity window.
+		field.Int64("totp_last_step").Default(0),
+		field.Enum("role").Values("user", "moderator", "admin").Default("user"),
+		// banned_at is set while the account is banned from signing in.
+		field.Time("banned_at").Optional().Nillable(),
+		// deleted_at is set when the account was deleted but its row kept,
+		// scrubbed, so its polls and votes survive anonymously.
+		field.Time("deleted_at").Optional().Nillable(),
+		// active is cleared when the account is deprovisioned, e.g. over
+		// SCIM; inactive users can't sign in but keep their history.
+		field.Bool("active").Default(true),
+		// external_id is the provisioning client's identifier for the user.
+		field.String("external_id").Optional().Nillable(),
 	}
 }
 
@@ -24,5 +52,21 @@ func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("polls", Poll.Type),
 		edge.To("votes", Vote.Type),
+		edge.To("sessions", Session.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("access_tokens", AccessToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("identities", Identity.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("tokens", UserToken.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("recovery_codes", RecoveryCode.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("groups", Group.Type).
+			Ref("members"),
+		edge.To("vote_history", VoteHistory.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("invited_polls", Poll.Type).
+			Ref("invited_users"),
 	}
 }
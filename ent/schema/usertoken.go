@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// UserToken holds the schema definition for expiring, single-use tokens
+// mailed to users, such as email verification and password reset links.
+type UserToken struct {
+	ent.Schema
+}
+
+// Fields of the UserToken.
+func (UserToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("purpose").Values("verify_email", "reset_password"),
+		field.String("token_hash").Unique().NotEmpty().Sensitive(),
+		field.Int("user_id"),
+		// email is the address the token was sent to; verifying it only
+		// counts if it is still the user's address.
+		field.String("email"),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("expires_at"),
+		field.Time("used_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the UserToken.
+func (UserToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("tokens").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
@@ -12,12 +12,24 @@ type Vote struct {
 	ent.Schema
 }
 
+// Mixin of the Vote.
+func (Vote) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
 // Fields defines the FK columns in snake_case.
 func (Vote) Fields() []ent.Field {
 	return []ent.Field{
 		field.Int("user_id"),
 		field.Int("poll_id"),
 		field.Int("option_id"),
+		// position orders the options within one voter's ballot, from 0;
+		// on ranked ballots it is the option's rank less one.
+		field.Int("position").Default(0),
+		// score is the voter's rating of the option on score and star polls.
+		field.Int("score").Optional().Nillable(),
 	}
 }
 
@@ -46,10 +58,14 @@ func (Vote) Edges() []ent.Edge {
 	}
 }
 
-// Composite index to enforce one vote per user per poll.
+// A ballot is the votes sharing user_id and poll_id. Each option appears
+// on it once, and since every ballot has a position 0, two ballots from the
+// same voter can't both be stored.
 func (Vote) Indexes() []ent.Index {
 	return []ent.Index{
-		index.Fields("user_id", "poll_id").
+		index.Fields("user_id", "poll_id", "option_id").
+			Unique(),
+		index.Fields("user_id", "poll_id", "position").
 			Unique(),
 	}
 }
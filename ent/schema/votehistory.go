@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// VoteHistory records every ballot a user cast, changed or retracted on a
+// poll, so audits see the original ballot and each revision after it.
+type VoteHistory struct {
+	ent.Schema
+}
+
+// Fields of the VoteHistory.
+func (VoteHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.Int("poll_id"),
+		field.Enum("action").Values("cast", "change", "retract"),
+		// option_ids and scores are the ballot as the action left it;
+		// both are empty after a retraction.
+		field.JSON("option_ids", []int{}),
+		field.JSON("scores", map[int]int{}).Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the VoteHistory.
+func (VoteHistory) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("vote_history").
+			Field("user_id").
+			Unique().
+			Required(),
+		edge.From("poll", Poll.Type).
+			Ref("vote_history").
+			Field("poll_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Audits read a poll's history voter by voter.
+func (VoteHistory) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("poll_id", "user_id"),
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
 	"strings"
+	"time"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -19,12 +20,20 @@ type Vote struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// UserID holds the value of the "user_id" field.
 	UserID int `json:"user_id,omitempty"`
 	// PollID holds the value of the "poll_id" field.
 	PollID int `json:"poll_id,omitempty"`
 	// OptionID holds the value of the "option_id" field.
 	OptionID int `json:"option_id,omitempty"`
+	// Position holds the value of the "position" field.
+	Position int `json:"position,omitempty"`
+	// Score holds the value of the "score" field.
+	Score *int `json:"score,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the VoteQuery when eager-loading is set.
 	Edges        VoteEdges `json:"edges"`
@@ -82,8 +91,10 @@ func (*Vote) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case vote.FieldID, vote.FieldUserID, vote.FieldPollID, vote.FieldOptionID:
+		case vote.FieldID, vote.FieldUserID, vote.FieldPollID, vote.FieldOptionID, vote.FieldPosition, vote.FieldScore:
 			values[i] = new(sql.NullInt64)
+		case vote.FieldCreatedAt, vote.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -105,6 +116,18 @@ func (v *Vote) assignValues(columns []string, values []any) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			v.ID = int(value.Int64)
+		case vote.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				v.CreatedAt = value.Time
+			}
+		case vote.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				v.UpdatedAt = value.Time
+			}
 		case vote.FieldUserID:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field user_id", values[i])
@@ -123,6 +146,19 @@ func (v *Vote) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				v.OptionID = int(value.Int64)
 			}
+		case vote.FieldPosition:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field position", values[i])
+			} else if value.Valid {
+				v.Position = int(value.Int64)
+			}
+		case vote.FieldScore:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score", values[i])
+			} else if value.Valid {
+				v.Score = new(int)
+				*v.Score = int(value.Int64)
+			}
 		default:
 			v.selectValues.Set(columns[i], values[i])
 		}
@@ -174,6 +210,12 @@ func (v *Vote) String() string {
 	var builder strings.Builder
 	builder.WriteString("Vote(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", v.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(v.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(v.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
 	builder.WriteString("user_id=")
 	builder.WriteString(fmt.Sprintf("%v", v.UserID))
 	builder.WriteString(", ")
@@ -182,6 +224,14 @@ func (v *Vote) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("option_id=")
 	builder.WriteString(fmt.Sprintf("%v", v.OptionID))
+	builder.WriteString(", ")
+	builder.WriteString("position=")
+	builder.WriteString(fmt.Sprintf("%v", v.Position))
+	builder.WriteString(", ")
+	if v := v.Score; v != nil {
+		builder.WriteString("score=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
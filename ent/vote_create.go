@@ -10,6 +10,7 @@ import (
 	"pollAppNew/ent/polloption"
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -22,6 +23,34 @@ type VoteCreate struct {
 	hooks    []Hook
 }
 
+// SetCreatedAt sets the "created_at" field.
+func (vc *VoteCreate) SetCreatedAt(t time.Time) *VoteCreate {
+	vc.mutation.SetCreatedAt(t)
+	return vc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableCreatedAt(t *time.Time) *VoteCreate {
+	if t != nil {
+		vc.SetCreatedAt(*t)
+	}
+	return vc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (vc *VoteCreate) SetUpdatedAt(t time.Time) *VoteCreate {
+	vc.mutation.SetUpdatedAt(t)
+	return vc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableUpdatedAt(t *time.Time) *VoteCreate {
+	if t != nil {
+		vc.SetUpdatedAt(*t)
+	}
+	return vc
+}
+
 // SetUserID sets the "user_id" field.
 func (vc *VoteCreate) SetUserID(i int) *VoteCreate {
 	vc.mutation.SetUserID(i)
@@ -40,6 +69,34 @@ func (vc *VoteCreate) SetOptionID(i int) *VoteCreate {
 	return vc
 }
 
+// SetPosition sets the "position" field.
+func (vc *VoteCreate) SetPosition(i int) *VoteCreate {
+	vc.mutation.SetPosition(i)
+	return vc
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (vc *VoteCreate) SetNillablePosition(i *int) *VoteCreate {
+	if i != nil {
+		vc.SetPosition(*i)
+	}
+	return vc
+}
+
+// SetScore sets the "score" field.
+func (vc *VoteCreate) SetScore(i int) *VoteCreate {
+	vc.mutation.SetScore(i)
+	return vc
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableScore(i *int) *VoteCreate {
+	if i != nil {
+		vc.SetScore(*i)
+	}
+	return vc
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vc *VoteCreate) SetUser(u *User) *VoteCreate {
 	return vc.SetUserID(u.ID)
@@ -62,6 +119,7 @@ func (vc *VoteCreate) Mutation() *VoteMutation {
 
 // Save creates the Vote in the database.
 func (vc *VoteCreate) Save(ctx context.Context) (*Vote, error) {
+	vc.defaults()
 	return withHooks(ctx, vc.sqlSave, vc.mutation, vc.hooks)
 }
 
@@ -87,8 +145,30 @@ func (vc *VoteCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (vc *VoteCreate) defaults() {
+	if _, ok := vc.mutation.CreatedAt(); !ok {
+		v := vote.DefaultCreatedAt()
+		vc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := vc.mutation.UpdatedAt(); !ok {
+		v := vote.DefaultUpdatedAt()
+		vc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := vc.mutation.Position(); !ok {
+		v := vote.DefaultPosition
+		vc.mutation.SetPosition(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (vc *VoteCreate) check() error {
+	if _, ok := vc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Vote.created_at"`)}
+	}
+	if _, ok := vc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Vote.updated_at"`)}
+	}
 	if _, ok := vc.mutation.UserID(); !ok {
 		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Vote.user_id"`)}
 	}
@@ -98,6 +178,9 @@ func (vc *VoteCreate) check() error {
 	if _, ok := vc.mutation.OptionID(); !ok {
 		return &ValidationError{Name: "option_id", err: errors.New(`ent: missing required field "Vote.option_id"`)}
 	}
+	if _, ok := vc.mutation.Position(); !ok {
+		return &ValidationError{Name: "position", err: errors.New(`ent: missing required field "Vote.position"`)}
+	}
 	if len(vc.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Vote.user"`)}
 	}
@@ -133,6 +216,22 @@ func (vc *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_node = &Vote{config: vc.config}
 		_spec = sqlgraph.NewCreateSpec(vote.Table, sqlgraph.NewFieldSpec(vote.FieldID, field.TypeInt))
 	)
+	if value, ok := vc.mutation.CreatedAt(); ok {
+		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := vc.mutation.UpdatedAt(); ok {
+		_spec.SetField(vote.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := vc.mutation.Position(); ok {
+		_spec.SetField(vote.FieldPosition, field.TypeInt, value)
+		_node.Position = value
+	}
+	if value, ok := vc.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+		_node.Score = &value
+	}
 	if nodes := vc.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -205,6 +304,7 @@ func (vcb *VoteCreateBulk) Save(ctx context.Context) ([]*Vote, error) {
 	for i := range vcb.builders {
 		func(i int, root context.Context) {
 			builder := vcb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*VoteMutation)
 				if !ok {
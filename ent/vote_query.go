@@ -370,12 +370,12 @@ func (vq *VoteQuery) WithOption(opts ...func(*PollOptionQuery)) *VoteQuery {
 // Example:
 //
 //	var v []struct {
-//		UserID int `json:"user_id,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Vote.Query().
-//		GroupBy(vote.FieldUserID).
+//		GroupBy(vote.FieldCreatedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (vq *VoteQuery) GroupBy(field string, fields ...string) *VoteGroupBy {
@@ -393,11 +393,11 @@ func (vq *VoteQuery) GroupBy(field string, fields ...string) *VoteGroupBy {
 // Example:
 //
 //	var v []struct {
-//		UserID int `json:"user_id,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //	}
 //
 //	client.Vote.Query().
-//		Select(vote.FieldUserID).
+//		Select(vote.FieldCreatedAt).
 //		Scan(ctx, &v)
 func (vq *VoteQuery) Select(fields ...string) *VoteSelect {
 	vq.ctx.Fields = append(vq.ctx.Fields, fields...)
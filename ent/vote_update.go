@@ -11,6 +11,7 @@ import (
 	"pollAppNew/ent/predicate"
 	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -30,6 +31,12 @@ func (vu *VoteUpdate) Where(ps ...predicate.Vote) *VoteUpdate {
 	return vu
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (vu *VoteUpdate) SetUpdatedAt(t time.Time) *VoteUpdate {
+	vu.mutation.SetUpdatedAt(t)
+	return vu
+}
+
 // SetUserID sets the "user_id" field.
 func (vu *VoteUpdate) SetUserID(i int) *VoteUpdate {
 	vu.mutation.SetUserID(i)
@@ -72,6 +79,54 @@ func (vu *VoteUpdate) SetNillableOptionID(i *int) *VoteUpdate {
 	return vu
 }
 
+// SetPosition sets the "position" field.
+func (vu *VoteUpdate) SetPosition(i int) *VoteUpdate {
+	vu.mutation.ResetPosition()
+	vu.mutation.SetPosition(i)
+	return vu
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillablePosition(i *int) *VoteUpdate {
+	if i != nil {
+		vu.SetPosition(*i)
+	}
+	return vu
+}
+
+// AddPosition adds i to the "position" field.
+func (vu *VoteUpdate) AddPosition(i int) *VoteUpdate {
+	vu.mutation.AddPosition(i)
+	return vu
+}
+
+// SetScore sets the "score" field.
+func (vu *VoteUpdate) SetScore(i int) *VoteUpdate {
+	vu.mutation.ResetScore()
+	vu.mutation.SetScore(i)
+	return vu
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillableScore(i *int) *VoteUpdate {
+	if i != nil {
+		vu.SetScore(*i)
+	}
+	return vu
+}
+
+// AddScore adds i to the "score" field.
+func (vu *VoteUpdate) AddScore(i int) *VoteUpdate {
+	vu.mutation.AddScore(i)
+	return vu
+}
+
+// ClearScore clears the value of the "score" field.
+func (vu *VoteUpdate) ClearScore() *VoteUpdate {
+	vu.mutation.ClearScore()
+	return vu
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vu *VoteUpdate) SetUser(u *User) *VoteUpdate {
 	return vu.SetUserID(u.ID)
@@ -112,6 +167,7 @@ func (vu *VoteUpdate) ClearOption() *VoteUpdate {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (vu *VoteUpdate) Save(ctx context.Context) (int, error) {
+	vu.defaults()
 	return withHooks(ctx, vu.sqlSave, vu.mutation, vu.hooks)
 }
 
@@ -137,6 +193,14 @@ func (vu *VoteUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (vu *VoteUpdate) defaults() {
+	if _, ok := vu.mutation.UpdatedAt(); !ok {
+		v := vote.UpdateDefaultUpdatedAt()
+		vu.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (vu *VoteUpdate) check() error {
 	if vu.mutation.UserCleared() && len(vu.mutation.UserIDs()) > 0 {
@@ -163,6 +227,24 @@ func (vu *VoteUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := vu.mutation.UpdatedAt(); ok {
+		_spec.SetField(vote.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := vu.mutation.Position(); ok {
+		_spec.SetField(vote.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := vu.mutation.AddedPosition(); ok {
+		_spec.AddField(vote.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := vu.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := vu.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if vu.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
 	if vu.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -270,6 +352,12 @@ type VoteUpdateOne struct {
 	mutation *VoteMutation
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (vuo *VoteUpdateOne) SetUpdatedAt(t time.Time) *VoteUpdateOne {
+	vuo.mutation.SetUpdatedAt(t)
+	return vuo
+}
+
 // SetUserID sets the "user_id" field.
 func (vuo *VoteUpdateOne) SetUserID(i int) *VoteUpdateOne {
 	vuo.mutation.SetUserID(i)
@@ -312,6 +400,54 @@ func (vuo *VoteUpdateOne) SetNillableOptionID(i *int) *VoteUpdateOne {
 	return vuo
 }
 
+// SetPosition sets the "position" field.
+func (vuo *VoteUpdateOne) SetPosition(i int) *VoteUpdateOne {
+	vuo.mutation.ResetPosition()
+	vuo.mutation.SetPosition(i)
+	return vuo
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillablePosition(i *int) *VoteUpdateOne {
+	if i != nil {
+		vuo.SetPosition(*i)
+	}
+	return vuo
+}
+
+// AddPosition adds i to the "position" field.
+func (vuo *VoteUpdateOne) AddPosition(i int) *VoteUpdateOne {
+	vuo.mutation.AddPosition(i)
+	return vuo
+}
+
+// SetScore sets the "score" field.
+func (vuo *VoteUpdateOne) SetScore(i int) *VoteUpdateOne {
+	vuo.mutation.ResetScore()
+	vuo.mutation.SetScore(i)
+	return vuo
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableScore(i *int) *VoteUpdateOne {
+	if i != nil {
+		vuo.SetScore(*i)
+	}
+	return vuo
+}
+
+// AddScore adds i to the "score" field.
+func (vuo *VoteUpdateOne) AddScore(i int) *VoteUpdateOne {
+	vuo.mutation.AddScore(i)
+	return vuo
+}
+
+// ClearScore clears the value of the "score" field.
+func (vuo *VoteUpdateOne) ClearScore() *VoteUpdateOne {
+	vuo.mutation.ClearScore()
+	return vuo
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vuo *VoteUpdateOne) SetUser(u *User) *VoteUpdateOne {
 	return vuo.SetUserID(u.ID)
@@ -365,6 +501,7 @@ func (vuo *VoteUpdateOne) Select(field string, fields ...string) *VoteUpdateOne
 
 // Save executes the query and returns the updated Vote entity.
 func (vuo *VoteUpdateOne) Save(ctx context.Context) (*Vote, error) {
+	vuo.defaults()
 	return withHooks(ctx, vuo.sqlSave, vuo.mutation, vuo.hooks)
 }
 
@@ -390,6 +527,14 @@ func (vuo *VoteUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (vuo *VoteUpdateOne) defaults() {
+	if _, ok := vuo.mutation.UpdatedAt(); !ok {
+		v := vote.UpdateDefaultUpdatedAt()
+		vuo.mutation.SetUpdatedAt(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (vuo *VoteUpdateOne) check() error {
 	if vuo.mutation.UserCleared() && len(vuo.mutation.UserIDs()) > 0 {
@@ -433,6 +578,24 @@ func (vuo *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error)
 			}
 		}
 	}
+	if value, ok := vuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(vote.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := vuo.mutation.Position(); ok {
+		_spec.SetField(vote.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := vuo.mutation.AddedPosition(); ok {
+		_spec.AddField(vote.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := vuo.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := vuo.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if vuo.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
 	if vuo.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -0,0 +1,216 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/votehistory"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// VoteHistory is the model entity for the VoteHistory schema.
+type VoteHistory struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// PollID holds the value of the "poll_id" field.
+	PollID int `json:"poll_id,omitempty"`
+	// Action holds the value of the "action" field.
+	Action votehistory.Action `json:"action,omitempty"`
+	// OptionIds holds the value of the "option_ids" field.
+	OptionIds []int `json:"option_ids,omitempty"`
+	// Scores holds the value of the "scores" field.
+	Scores map[int]int `json:"scores,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the VoteHistoryQuery when eager-loading is set.
+	Edges        VoteHistoryEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// VoteHistoryEdges holds the relations/edges for other nodes in the graph.
+type VoteHistoryEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// Poll holds the value of the poll edge.
+	Poll *Poll `json:"poll,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e VoteHistoryEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e VoteHistoryEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*VoteHistory) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case votehistory.FieldOptionIds, votehistory.FieldScores:
+			values[i] = new([]byte)
+		case votehistory.FieldID, votehistory.FieldUserID, votehistory.FieldPollID:
+			values[i] = new(sql.NullInt64)
+		case votehistory.FieldAction:
+			values[i] = new(sql.NullString)
+		case votehistory.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the VoteHistory fields.
+func (vh *VoteHistory) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case votehistory.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			vh.ID = int(value.Int64)
+		case votehistory.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				vh.UserID = int(value.Int64)
+			}
+		case votehistory.FieldPollID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field poll_id", values[i])
+			} else if value.Valid {
+				vh.PollID = int(value.Int64)
+			}
+		case votehistory.FieldAction:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action", values[i])
+			} else if value.Valid {
+				vh.Action = votehistory.Action(value.String)
+			}
+		case votehistory.FieldOptionIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field option_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &vh.OptionIds); err != nil {
+					return fmt.Errorf("unmarshal field option_ids: %w", err)
+				}
+			}
+		case votehistory.FieldScores:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scores", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &vh.Scores); err != nil {
+					return fmt.Errorf("unmarshal field scores: %w", err)
+				}
+			}
+		case votehistory.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				vh.CreatedAt = value.Time
+			}
+		default:
+			vh.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the VoteHistory.
+// This includes values selected through modifiers, order, etc.
+func (vh *VoteHistory) Value(name string) (ent.Value, error) {
+	return vh.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the VoteHistory entity.
+func (vh *VoteHistory) QueryUser() *UserQuery {
+	return NewVoteHistoryClient(vh.config).QueryUser(vh)
+}
+
+// QueryPoll queries the "poll" edge of the VoteHistory entity.
+func (vh *VoteHistory) QueryPoll() *PollQuery {
+	return NewVoteHistoryClient(vh.config).QueryPoll(vh)
+}
+
+// Update returns a builder for updating this VoteHistory.
+// Note that you need to call VoteHistory.Unwrap() before calling this method if this VoteHistory
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (vh *VoteHistory) Update() *VoteHistoryUpdateOne {
+	return NewVoteHistoryClient(vh.config).UpdateOne(vh)
+}
+
+// Unwrap unwraps the VoteHistory entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (vh *VoteHistory) Unwrap() *VoteHistory {
+	_tx, ok := vh.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: VoteHistory is not a transactional entity")
+	}
+	vh.config.driver = _tx.drv
+	return vh
+}
+
+// String implements the fmt.Stringer.
+func (vh *VoteHistory) String() string {
+	var builder strings.Builder
+	builder.WriteString("VoteHistory(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", vh.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", vh.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("poll_id=")
+	builder.WriteString(fmt.Sprintf("%v", vh.PollID))
+	builder.WriteString(", ")
+	builder.WriteString("action=")
+	builder.WriteString(fmt.Sprintf("%v", vh.Action))
+	builder.WriteString(", ")
+	builder.WriteString("option_ids=")
+	builder.WriteString(fmt.Sprintf("%v", vh.OptionIds))
+	builder.WriteString(", ")
+	builder.WriteString("scores=")
+	builder.WriteString(fmt.Sprintf("%v", vh.Scores))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(vh.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// VoteHistories is a parsable slice of VoteHistory.
+type VoteHistories []*VoteHistory
@@ -0,0 +1,313 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/votehistory"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// VoteHistoryCreate is the builder for creating a VoteHistory entity.
+type VoteHistoryCreate struct {
+	config
+	mutation *VoteHistoryMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (vhc *VoteHistoryCreate) SetUserID(i int) *VoteHistoryCreate {
+	vhc.mutation.SetUserID(i)
+	return vhc
+}
+
+// SetPollID sets the "poll_id" field.
+func (vhc *VoteHistoryCreate) SetPollID(i int) *VoteHistoryCreate {
+	vhc.mutation.SetPollID(i)
+	return vhc
+}
+
+// SetAction sets the "action" field.
+func (vhc *VoteHistoryCreate) SetAction(v votehistory.Action) *VoteHistoryCreate {
+	vhc.mutation.SetAction(v)
+	return vhc
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (vhc *VoteHistoryCreate) SetOptionIds(i []int) *VoteHistoryCreate {
+	vhc.mutation.SetOptionIds(i)
+	return vhc
+}
+
+// SetScores sets the "scores" field.
+func (vhc *VoteHistoryCreate) SetScores(m map[int]int) *VoteHistoryCreate {
+	vhc.mutation.SetScores(m)
+	return vhc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (vhc *VoteHistoryCreate) SetCreatedAt(t time.Time) *VoteHistoryCreate {
+	vhc.mutation.SetCreatedAt(t)
+	return vhc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (vhc *VoteHistoryCreate) SetNillableCreatedAt(t *time.Time) *VoteHistoryCreate {
+	if t != nil {
+		vhc.SetCreatedAt(*t)
+	}
+	return vhc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (vhc *VoteHistoryCreate) SetUser(u *User) *VoteHistoryCreate {
+	return vhc.SetUserID(u.ID)
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (vhc *VoteHistoryCreate) SetPoll(p *Poll) *VoteHistoryCreate {
+	return vhc.SetPollID(p.ID)
+}
+
+// Mutation returns the VoteHistoryMutation object of the builder.
+func (vhc *VoteHistoryCreate) Mutation() *VoteHistoryMutation {
+	return vhc.mutation
+}
+
+// Save creates the VoteHistory in the database.
+func (vhc *VoteHistoryCreate) Save(ctx context.Context) (*VoteHistory, error) {
+	vhc.defaults()
+	return withHooks(ctx, vhc.sqlSave, vhc.mutation, vhc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (vhc *VoteHistoryCreate) SaveX(ctx context.Context) *VoteHistory {
+	v, err := vhc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (vhc *VoteHistoryCreate) Exec(ctx context.Context) error {
+	_, err := vhc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhc *VoteHistoryCreate) ExecX(ctx context.Context) {
+	if err := vhc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (vhc *VoteHistoryCreate) defaults() {
+	if _, ok := vhc.mutation.CreatedAt(); !ok {
+		v := votehistory.DefaultCreatedAt()
+		vhc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (vhc *VoteHistoryCreate) check() error {
+	if _, ok := vhc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "VoteHistory.user_id"`)}
+	}
+	if _, ok := vhc.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "VoteHistory.poll_id"`)}
+	}
+	if _, ok := vhc.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`ent: missing required field "VoteHistory.action"`)}
+	}
+	if v, ok := vhc.mutation.Action(); ok {
+		if err := votehistory.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "VoteHistory.action": %w`, err)}
+		}
+	}
+	if _, ok := vhc.mutation.OptionIds(); !ok {
+		return &ValidationError{Name: "option_ids", err: errors.New(`ent: missing required field "VoteHistory.option_ids"`)}
+	}
+	if _, ok := vhc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "VoteHistory.created_at"`)}
+	}
+	if len(vhc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "VoteHistory.user"`)}
+	}
+	if len(vhc.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "VoteHistory.poll"`)}
+	}
+	return nil
+}
+
+func (vhc *VoteHistoryCreate) sqlSave(ctx context.Context) (*VoteHistory, error) {
+	if err := vhc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := vhc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, vhc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	vhc.mutation.id = &_node.ID
+	vhc.mutation.done = true
+	return _node, nil
+}
+
+func (vhc *VoteHistoryCreate) createSpec() (*VoteHistory, *sqlgraph.CreateSpec) {
+	var (
+		_node = &VoteHistory{config: vhc.config}
+		_spec = sqlgraph.NewCreateSpec(votehistory.Table, sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt))
+	)
+	if value, ok := vhc.mutation.Action(); ok {
+		_spec.SetField(votehistory.FieldAction, field.TypeEnum, value)
+		_node.Action = value
+	}
+	if value, ok := vhc.mutation.OptionIds(); ok {
+		_spec.SetField(votehistory.FieldOptionIds, field.TypeJSON, value)
+		_node.OptionIds = value
+	}
+	if value, ok := vhc.mutation.Scores(); ok {
+		_spec.SetField(votehistory.FieldScores, field.TypeJSON, value)
+		_node.Scores = value
+	}
+	if value, ok := vhc.mutation.CreatedAt(); ok {
+		_spec.SetField(votehistory.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := vhc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.UserTable,
+			Columns: []string{votehistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := vhc.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.PollTable,
+			Columns: []string{votehistory.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// VoteHistoryCreateBulk is the builder for creating many VoteHistory entities in bulk.
+type VoteHistoryCreateBulk struct {
+	config
+	err      error
+	builders []*VoteHistoryCreate
+}
+
+// Save creates the VoteHistory entities in the database.
+func (vhcb *VoteHistoryCreateBulk) Save(ctx context.Context) ([]*VoteHistory, error) {
+	if vhcb.err != nil {
+		return nil, vhcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(vhcb.builders))
+	nodes := make([]*VoteHistory, len(vhcb.builders))
+	mutators := make([]Mutator, len(vhcb.builders))
+	for i := range vhcb.builders {
+		func(i int, root context.Context) {
+			builder := vhcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*VoteHistoryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, vhcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, vhcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, vhcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vhcb *VoteHistoryCreateBulk) SaveX(ctx context.Context) []*VoteHistory {
+	v, err := vhcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (vhcb *VoteHistoryCreateBulk) Exec(ctx context.Context) error {
+	_, err := vhcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhcb *VoteHistoryCreateBulk) ExecX(ctx context.Context) {
+	if err := vhcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/votehistory"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// VoteHistoryDelete is the builder for deleting a VoteHistory entity.
+type VoteHistoryDelete struct {
+	config
+	hooks    []Hook
+	mutation *VoteHistoryMutation
+}
+
+// Where appends a list predicates to the VoteHistoryDelete builder.
+func (vhd *VoteHistoryDelete) Where(ps ...predicate.VoteHistory) *VoteHistoryDelete {
+	vhd.mutation.Where(ps...)
+	return vhd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (vhd *VoteHistoryDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, vhd.sqlExec, vhd.mutation, vhd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhd *VoteHistoryDelete) ExecX(ctx context.Context) int {
+	n, err := vhd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (vhd *VoteHistoryDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(votehistory.Table, sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt))
+	if ps := vhd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, vhd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	vhd.mutation.done = true
+	return affected, err
+}
+
+// VoteHistoryDeleteOne is the builder for deleting a single VoteHistory entity.
+type VoteHistoryDeleteOne struct {
+	vhd *VoteHistoryDelete
+}
+
+// Where appends a list predicates to the VoteHistoryDelete builder.
+func (vhdo *VoteHistoryDeleteOne) Where(ps ...predicate.VoteHistory) *VoteHistoryDeleteOne {
+	vhdo.vhd.mutation.Where(ps...)
+	return vhdo
+}
+
+// Exec executes the deletion query.
+func (vhdo *VoteHistoryDeleteOne) Exec(ctx context.Context) error {
+	n, err := vhdo.vhd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{votehistory.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhdo *VoteHistoryDeleteOne) ExecX(ctx context.Context) {
+	if err := vhdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,681 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/votehistory"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// VoteHistoryQuery is the builder for querying VoteHistory entities.
+type VoteHistoryQuery struct {
+	config
+	ctx        *QueryContext
+	order      []votehistory.OrderOption
+	inters     []Interceptor
+	predicates []predicate.VoteHistory
+	withUser   *UserQuery
+	withPoll   *PollQuery
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the VoteHistoryQuery builder.
+func (vhq *VoteHistoryQuery) Where(ps ...predicate.VoteHistory) *VoteHistoryQuery {
+	vhq.predicates = append(vhq.predicates, ps...)
+	return vhq
+}
+
+// Limit the number of records to be returned by this query.
+func (vhq *VoteHistoryQuery) Limit(limit int) *VoteHistoryQuery {
+	vhq.ctx.Limit = &limit
+	return vhq
+}
+
+// Offset to start from.
+func (vhq *VoteHistoryQuery) Offset(offset int) *VoteHistoryQuery {
+	vhq.ctx.Offset = &offset
+	return vhq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (vhq *VoteHistoryQuery) Unique(unique bool) *VoteHistoryQuery {
+	vhq.ctx.Unique = &unique
+	return vhq
+}
+
+// Order specifies how the records should be ordered.
+func (vhq *VoteHistoryQuery) Order(o ...votehistory.OrderOption) *VoteHistoryQuery {
+	vhq.order = append(vhq.order, o...)
+	return vhq
+}
+
+// QueryUser chains the current query on the "user" edge.
+func (vhq *VoteHistoryQuery) QueryUser() *UserQuery {
+	query := (&UserClient{config: vhq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := vhq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := vhq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(votehistory.Table, votehistory.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, votehistory.UserTable, votehistory.UserColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(vhq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryPoll chains the current query on the "poll" edge.
+func (vhq *VoteHistoryQuery) QueryPoll() *PollQuery {
+	query := (&PollClient{config: vhq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := vhq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := vhq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(votehistory.Table, votehistory.FieldID, selector),
+			sqlgraph.To(poll.Table, poll.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, votehistory.PollTable, votehistory.PollColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(vhq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first VoteHistory entity from the query.
+// Returns a *NotFoundError when no VoteHistory was found.
+func (vhq *VoteHistoryQuery) First(ctx context.Context) (*VoteHistory, error) {
+	nodes, err := vhq.Limit(1).All(setContextOp(ctx, vhq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{votehistory.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) FirstX(ctx context.Context) *VoteHistory {
+	node, err := vhq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first VoteHistory ID from the query.
+// Returns a *NotFoundError when no VoteHistory ID was found.
+func (vhq *VoteHistoryQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = vhq.Limit(1).IDs(setContextOp(ctx, vhq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{votehistory.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) FirstIDX(ctx context.Context) int {
+	id, err := vhq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single VoteHistory entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one VoteHistory entity is found.
+// Returns a *NotFoundError when no VoteHistory entities are found.
+func (vhq *VoteHistoryQuery) Only(ctx context.Context) (*VoteHistory, error) {
+	nodes, err := vhq.Limit(2).All(setContextOp(ctx, vhq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{votehistory.Label}
+	default:
+		return nil, &NotSingularError{votehistory.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) OnlyX(ctx context.Context) *VoteHistory {
+	node, err := vhq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only VoteHistory ID in the query.
+// Returns a *NotSingularError when more than one VoteHistory ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (vhq *VoteHistoryQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = vhq.Limit(2).IDs(setContextOp(ctx, vhq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{votehistory.Label}
+	default:
+		err = &NotSingularError{votehistory.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) OnlyIDX(ctx context.Context) int {
+	id, err := vhq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of VoteHistories.
+func (vhq *VoteHistoryQuery) All(ctx context.Context) ([]*VoteHistory, error) {
+	ctx = setContextOp(ctx, vhq.ctx, ent.OpQueryAll)
+	if err := vhq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*VoteHistory, *VoteHistoryQuery]()
+	return withInterceptors[[]*VoteHistory](ctx, vhq, qr, vhq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) AllX(ctx context.Context) []*VoteHistory {
+	nodes, err := vhq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of VoteHistory IDs.
+func (vhq *VoteHistoryQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if vhq.ctx.Unique == nil && vhq.path != nil {
+		vhq.Unique(true)
+	}
+	ctx = setContextOp(ctx, vhq.ctx, ent.OpQueryIDs)
+	if err = vhq.Select(votehistory.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) IDsX(ctx context.Context) []int {
+	ids, err := vhq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (vhq *VoteHistoryQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, vhq.ctx, ent.OpQueryCount)
+	if err := vhq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, vhq, querierCount[*VoteHistoryQuery](), vhq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) CountX(ctx context.Context) int {
+	count, err := vhq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (vhq *VoteHistoryQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, vhq.ctx, ent.OpQueryExist)
+	switch _, err := vhq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (vhq *VoteHistoryQuery) ExistX(ctx context.Context) bool {
+	exist, err := vhq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the VoteHistoryQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (vhq *VoteHistoryQuery) Clone() *VoteHistoryQuery {
+	if vhq == nil {
+		return nil
+	}
+	return &VoteHistoryQuery{
+		config:     vhq.config,
+		ctx:        vhq.ctx.Clone(),
+		order:      append([]votehistory.OrderOption{}, vhq.order...),
+		inters:     append([]Interceptor{}, vhq.inters...),
+		predicates: append([]predicate.VoteHistory{}, vhq.predicates...),
+		withUser:   vhq.withUser.Clone(),
+		withPoll:   vhq.withPoll.Clone(),
+		// clone intermediate query.
+		sql:  vhq.sql.Clone(),
+		path: vhq.path,
+	}
+}
+
+// WithUser tells the query-builder to eager-load the nodes that are connected to
+// the "user" edge. The optional arguments are used to configure the query builder of the edge.
+func (vhq *VoteHistoryQuery) WithUser(opts ...func(*UserQuery)) *VoteHistoryQuery {
+	query := (&UserClient{config: vhq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	vhq.withUser = query
+	return vhq
+}
+
+// WithPoll tells the query-builder to eager-load the nodes that are connected to
+// the "poll" edge. The optional arguments are used to configure the query builder of the edge.
+func (vhq *VoteHistoryQuery) WithPoll(opts ...func(*PollQuery)) *VoteHistoryQuery {
+	query := (&PollClient{config: vhq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	vhq.withPoll = query
+	return vhq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int `json:"user_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.VoteHistory.Query().
+//		GroupBy(votehistory.FieldUserID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (vhq *VoteHistoryQuery) GroupBy(field string, fields ...string) *VoteHistoryGroupBy {
+	vhq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &VoteHistoryGroupBy{build: vhq}
+	grbuild.flds = &vhq.ctx.Fields
+	grbuild.label = votehistory.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int `json:"user_id,omitempty"`
+//	}
+//
+//	client.VoteHistory.Query().
+//		Select(votehistory.FieldUserID).
+//		Scan(ctx, &v)
+func (vhq *VoteHistoryQuery) Select(fields ...string) *VoteHistorySelect {
+	vhq.ctx.Fields = append(vhq.ctx.Fields, fields...)
+	sbuild := &VoteHistorySelect{VoteHistoryQuery: vhq}
+	sbuild.label = votehistory.Label
+	sbuild.flds, sbuild.scan = &vhq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a VoteHistorySelect configured with the given aggregations.
+func (vhq *VoteHistoryQuery) Aggregate(fns ...AggregateFunc) *VoteHistorySelect {
+	return vhq.Select().Aggregate(fns...)
+}
+
+func (vhq *VoteHistoryQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range vhq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, vhq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range vhq.ctx.Fields {
+		if !votehistory.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if vhq.path != nil {
+		prev, err := vhq.path(ctx)
+		if err != nil {
+			return err
+		}
+		vhq.sql = prev
+	}
+	return nil
+}
+
+func (vhq *VoteHistoryQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*VoteHistory, error) {
+	var (
+		nodes       = []*VoteHistory{}
+		_spec       = vhq.querySpec()
+		loadedTypes = [2]bool{
+			vhq.withUser != nil,
+			vhq.withPoll != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*VoteHistory).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &VoteHistory{config: vhq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, vhq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := vhq.withUser; query != nil {
+		if err := vhq.loadUser(ctx, query, nodes, nil,
+			func(n *VoteHistory, e *User) { n.Edges.User = e }); err != nil {
+			return nil, err
+		}
+	}
+	if query := vhq.withPoll; query != nil {
+		if err := vhq.loadPoll(ctx, query, nodes, nil,
+			func(n *VoteHistory, e *Poll) { n.Edges.Poll = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (vhq *VoteHistoryQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*VoteHistory, init func(*VoteHistory), assign func(*VoteHistory, *User)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*VoteHistory)
+	for i := range nodes {
+		fk := nodes[i].UserID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "user_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+func (vhq *VoteHistoryQuery) loadPoll(ctx context.Context, query *PollQuery, nodes []*VoteHistory, init func(*VoteHistory), assign func(*VoteHistory, *Poll)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*VoteHistory)
+	for i := range nodes {
+		fk := nodes[i].PollID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(poll.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "poll_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (vhq *VoteHistoryQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := vhq.querySpec()
+	_spec.Node.Columns = vhq.ctx.Fields
+	if len(vhq.ctx.Fields) > 0 {
+		_spec.Unique = vhq.ctx.Unique != nil && *vhq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, vhq.driver, _spec)
+}
+
+func (vhq *VoteHistoryQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(votehistory.Table, votehistory.Columns, sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt))
+	_spec.From = vhq.sql
+	if unique := vhq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if vhq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := vhq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, votehistory.FieldID)
+		for i := range fields {
+			if fields[i] != votehistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+		if vhq.withUser != nil {
+			_spec.Node.AddColumnOnce(votehistory.FieldUserID)
+		}
+		if vhq.withPoll != nil {
+			_spec.Node.AddColumnOnce(votehistory.FieldPollID)
+		}
+	}
+	if ps := vhq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := vhq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := vhq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := vhq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (vhq *VoteHistoryQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(vhq.driver.Dialect())
+	t1 := builder.Table(votehistory.Table)
+	columns := vhq.ctx.Fields
+	if len(columns) == 0 {
+		columns = votehistory.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if vhq.sql != nil {
+		selector = vhq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if vhq.ctx.Unique != nil && *vhq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range vhq.predicates {
+		p(selector)
+	}
+	for _, p := range vhq.order {
+		p(selector)
+	}
+	if offset := vhq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := vhq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// VoteHistoryGroupBy is the group-by builder for VoteHistory entities.
+type VoteHistoryGroupBy struct {
+	selector
+	build *VoteHistoryQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (vhgb *VoteHistoryGroupBy) Aggregate(fns ...AggregateFunc) *VoteHistoryGroupBy {
+	vhgb.fns = append(vhgb.fns, fns...)
+	return vhgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (vhgb *VoteHistoryGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, vhgb.build.ctx, ent.OpQueryGroupBy)
+	if err := vhgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*VoteHistoryQuery, *VoteHistoryGroupBy](ctx, vhgb.build, vhgb, vhgb.build.inters, v)
+}
+
+func (vhgb *VoteHistoryGroupBy) sqlScan(ctx context.Context, root *VoteHistoryQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(vhgb.fns))
+	for _, fn := range vhgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*vhgb.flds)+len(vhgb.fns))
+		for _, f := range *vhgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*vhgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := vhgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// VoteHistorySelect is the builder for selecting fields of VoteHistory entities.
+type VoteHistorySelect struct {
+	*VoteHistoryQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (vhs *VoteHistorySelect) Aggregate(fns ...AggregateFunc) *VoteHistorySelect {
+	vhs.fns = append(vhs.fns, fns...)
+	return vhs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (vhs *VoteHistorySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, vhs.ctx, ent.OpQuerySelect)
+	if err := vhs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*VoteHistoryQuery, *VoteHistorySelect](ctx, vhs.VoteHistoryQuery, vhs, vhs.inters, v)
+}
+
+func (vhs *VoteHistorySelect) sqlScan(ctx context.Context, root *VoteHistoryQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(vhs.fns))
+	for _, fn := range vhs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*vhs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := vhs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,542 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/votehistory"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// VoteHistoryUpdate is the builder for updating VoteHistory entities.
+type VoteHistoryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *VoteHistoryMutation
+}
+
+// Where appends a list predicates to the VoteHistoryUpdate builder.
+func (vhu *VoteHistoryUpdate) Where(ps ...predicate.VoteHistory) *VoteHistoryUpdate {
+	vhu.mutation.Where(ps...)
+	return vhu
+}
+
+// SetUserID sets the "user_id" field.
+func (vhu *VoteHistoryUpdate) SetUserID(i int) *VoteHistoryUpdate {
+	vhu.mutation.SetUserID(i)
+	return vhu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (vhu *VoteHistoryUpdate) SetNillableUserID(i *int) *VoteHistoryUpdate {
+	if i != nil {
+		vhu.SetUserID(*i)
+	}
+	return vhu
+}
+
+// SetPollID sets the "poll_id" field.
+func (vhu *VoteHistoryUpdate) SetPollID(i int) *VoteHistoryUpdate {
+	vhu.mutation.SetPollID(i)
+	return vhu
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (vhu *VoteHistoryUpdate) SetNillablePollID(i *int) *VoteHistoryUpdate {
+	if i != nil {
+		vhu.SetPollID(*i)
+	}
+	return vhu
+}
+
+// SetAction sets the "action" field.
+func (vhu *VoteHistoryUpdate) SetAction(v votehistory.Action) *VoteHistoryUpdate {
+	vhu.mutation.SetAction(v)
+	return vhu
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (vhu *VoteHistoryUpdate) SetNillableAction(v *votehistory.Action) *VoteHistoryUpdate {
+	if v != nil {
+		vhu.SetAction(*v)
+	}
+	return vhu
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (vhu *VoteHistoryUpdate) SetOptionIds(i []int) *VoteHistoryUpdate {
+	vhu.mutation.SetOptionIds(i)
+	return vhu
+}
+
+// AppendOptionIds appends i to the "option_ids" field.
+func (vhu *VoteHistoryUpdate) AppendOptionIds(i []int) *VoteHistoryUpdate {
+	vhu.mutation.AppendOptionIds(i)
+	return vhu
+}
+
+// SetScores sets the "scores" field.
+func (vhu *VoteHistoryUpdate) SetScores(m map[int]int) *VoteHistoryUpdate {
+	vhu.mutation.SetScores(m)
+	return vhu
+}
+
+// ClearScores clears the value of the "scores" field.
+func (vhu *VoteHistoryUpdate) ClearScores() *VoteHistoryUpdate {
+	vhu.mutation.ClearScores()
+	return vhu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (vhu *VoteHistoryUpdate) SetUser(u *User) *VoteHistoryUpdate {
+	return vhu.SetUserID(u.ID)
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (vhu *VoteHistoryUpdate) SetPoll(p *Poll) *VoteHistoryUpdate {
+	return vhu.SetPollID(p.ID)
+}
+
+// Mutation returns the VoteHistoryMutation object of the builder.
+func (vhu *VoteHistoryUpdate) Mutation() *VoteHistoryMutation {
+	return vhu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (vhu *VoteHistoryUpdate) ClearUser() *VoteHistoryUpdate {
+	vhu.mutation.ClearUser()
+	return vhu
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (vhu *VoteHistoryUpdate) ClearPoll() *VoteHistoryUpdate {
+	vhu.mutation.ClearPoll()
+	return vhu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (vhu *VoteHistoryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, vhu.sqlSave, vhu.mutation, vhu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vhu *VoteHistoryUpdate) SaveX(ctx context.Context) int {
+	affected, err := vhu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (vhu *VoteHistoryUpdate) Exec(ctx context.Context) error {
+	_, err := vhu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhu *VoteHistoryUpdate) ExecX(ctx context.Context) {
+	if err := vhu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (vhu *VoteHistoryUpdate) check() error {
+	if v, ok := vhu.mutation.Action(); ok {
+		if err := votehistory.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "VoteHistory.action": %w`, err)}
+		}
+	}
+	if vhu.mutation.UserCleared() && len(vhu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "VoteHistory.user"`)
+	}
+	if vhu.mutation.PollCleared() && len(vhu.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "VoteHistory.poll"`)
+	}
+	return nil
+}
+
+func (vhu *VoteHistoryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := vhu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(votehistory.Table, votehistory.Columns, sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt))
+	if ps := vhu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := vhu.mutation.Action(); ok {
+		_spec.SetField(votehistory.FieldAction, field.TypeEnum, value)
+	}
+	if value, ok := vhu.mutation.OptionIds(); ok {
+		_spec.SetField(votehistory.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := vhu.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, votehistory.FieldOptionIds, value)
+		})
+	}
+	if value, ok := vhu.mutation.Scores(); ok {
+		_spec.SetField(votehistory.FieldScores, field.TypeJSON, value)
+	}
+	if vhu.mutation.ScoresCleared() {
+		_spec.ClearField(votehistory.FieldScores, field.TypeJSON)
+	}
+	if vhu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.UserTable,
+			Columns: []string{votehistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := vhu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.UserTable,
+			Columns: []string{votehistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if vhu.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.PollTable,
+			Columns: []string{votehistory.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := vhu.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.PollTable,
+			Columns: []string{votehistory.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, vhu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{votehistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	vhu.mutation.done = true
+	return n, nil
+}
+
+// VoteHistoryUpdateOne is the builder for updating a single VoteHistory entity.
+type VoteHistoryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *VoteHistoryMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (vhuo *VoteHistoryUpdateOne) SetUserID(i int) *VoteHistoryUpdateOne {
+	vhuo.mutation.SetUserID(i)
+	return vhuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (vhuo *VoteHistoryUpdateOne) SetNillableUserID(i *int) *VoteHistoryUpdateOne {
+	if i != nil {
+		vhuo.SetUserID(*i)
+	}
+	return vhuo
+}
+
+// SetPollID sets the "poll_id" field.
+func (vhuo *VoteHistoryUpdateOne) SetPollID(i int) *VoteHistoryUpdateOne {
+	vhuo.mutation.SetPollID(i)
+	return vhuo
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (vhuo *VoteHistoryUpdateOne) SetNillablePollID(i *int) *VoteHistoryUpdateOne {
+	if i != nil {
+		vhuo.SetPollID(*i)
+	}
+	return vhuo
+}
+
+// SetAction sets the "action" field.
+func (vhuo *VoteHistoryUpdateOne) SetAction(v votehistory.Action) *VoteHistoryUpdateOne {
+	vhuo.mutation.SetAction(v)
+	return vhuo
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (vhuo *VoteHistoryUpdateOne) SetNillableAction(v *votehistory.Action) *VoteHistoryUpdateOne {
+	if v != nil {
+		vhuo.SetAction(*v)
+	}
+	return vhuo
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (vhuo *VoteHistoryUpdateOne) SetOptionIds(i []int) *VoteHistoryUpdateOne {
+	vhuo.mutation.SetOptionIds(i)
+	return vhuo
+}
+
+// AppendOptionIds appends i to the "option_ids" field.
+func (vhuo *VoteHistoryUpdateOne) AppendOptionIds(i []int) *VoteHistoryUpdateOne {
+	vhuo.mutation.AppendOptionIds(i)
+	return vhuo
+}
+
+// SetScores sets the "scores" field.
+func (vhuo *VoteHistoryUpdateOne) SetScores(m map[int]int) *VoteHistoryUpdateOne {
+	vhuo.mutation.SetScores(m)
+	return vhuo
+}
+
+// ClearScores clears the value of the "scores" field.
+func (vhuo *VoteHistoryUpdateOne) ClearScores() *VoteHistoryUpdateOne {
+	vhuo.mutation.ClearScores()
+	return vhuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (vhuo *VoteHistoryUpdateOne) SetUser(u *User) *VoteHistoryUpdateOne {
+	return vhuo.SetUserID(u.ID)
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (vhuo *VoteHistoryUpdateOne) SetPoll(p *Poll) *VoteHistoryUpdateOne {
+	return vhuo.SetPollID(p.ID)
+}
+
+// Mutation returns the VoteHistoryMutation object of the builder.
+func (vhuo *VoteHistoryUpdateOne) Mutation() *VoteHistoryMutation {
+	return vhuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (vhuo *VoteHistoryUpdateOne) ClearUser() *VoteHistoryUpdateOne {
+	vhuo.mutation.ClearUser()
+	return vhuo
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (vhuo *VoteHistoryUpdateOne) ClearPoll() *VoteHistoryUpdateOne {
+	vhuo.mutation.ClearPoll()
+	return vhuo
+}
+
+// Where appends a list predicates to the VoteHistoryUpdate builder.
+func (vhuo *VoteHistoryUpdateOne) Where(ps ...predicate.VoteHistory) *VoteHistoryUpdateOne {
+	vhuo.mutation.Where(ps...)
+	return vhuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (vhuo *VoteHistoryUpdateOne) Select(field string, fields ...string) *VoteHistoryUpdateOne {
+	vhuo.fields = append([]string{field}, fields...)
+	return vhuo
+}
+
+// Save executes the query and returns the updated VoteHistory entity.
+func (vhuo *VoteHistoryUpdateOne) Save(ctx context.Context) (*VoteHistory, error) {
+	return withHooks(ctx, vhuo.sqlSave, vhuo.mutation, vhuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vhuo *VoteHistoryUpdateOne) SaveX(ctx context.Context) *VoteHistory {
+	node, err := vhuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (vhuo *VoteHistoryUpdateOne) Exec(ctx context.Context) error {
+	_, err := vhuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vhuo *VoteHistoryUpdateOne) ExecX(ctx context.Context) {
+	if err := vhuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (vhuo *VoteHistoryUpdateOne) check() error {
+	if v, ok := vhuo.mutation.Action(); ok {
+		if err := votehistory.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "VoteHistory.action": %w`, err)}
+		}
+	}
+	if vhuo.mutation.UserCleared() && len(vhuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "VoteHistory.user"`)
+	}
+	if vhuo.mutation.PollCleared() && len(vhuo.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "VoteHistory.poll"`)
+	}
+	return nil
+}
+
+func (vhuo *VoteHistoryUpdateOne) sqlSave(ctx context.Context) (_node *VoteHistory, err error) {
+	if err := vhuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(votehistory.Table, votehistory.Columns, sqlgraph.NewFieldSpec(votehistory.FieldID, field.TypeInt))
+	id, ok := vhuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "VoteHistory.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := vhuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, votehistory.FieldID)
+		for _, f := range fields {
+			if !votehistory.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != votehistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := vhuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := vhuo.mutation.Action(); ok {
+		_spec.SetField(votehistory.FieldAction, field.TypeEnum, value)
+	}
+	if value, ok := vhuo.mutation.OptionIds(); ok {
+		_spec.SetField(votehistory.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := vhuo.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, votehistory.FieldOptionIds, value)
+		})
+	}
+	if value, ok := vhuo.mutation.Scores(); ok {
+		_spec.SetField(votehistory.FieldScores, field.TypeJSON, value)
+	}
+	if vhuo.mutation.ScoresCleared() {
+		_spec.ClearField(votehistory.FieldScores, field.TypeJSON)
+	}
+	if vhuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.UserTable,
+			Columns: []string{votehistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := vhuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.UserTable,
+			Columns: []string{votehistory.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if vhuo.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.PollTable,
+			Columns: []string{votehistory.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := vhuo.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   votehistory.PollTable,
+			Columns: []string{votehistory.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &VoteHistory{config: vhuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, vhuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{votehistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	vhuo.mutation.done = true
+	return _node, nil
+}
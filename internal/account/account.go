@@ -0,0 +1,233 @@
+// internal/account/account.go
+package account
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/accesstoken"
+	"pollAppNew/ent/identity"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/polloption"
+	"pollAppNew/ent/recoverycode"
+	"pollAppNew/ent/session"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/usertoken"
+	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+)
+
+// Deletion policies for what happens to a deleted user's polls and votes.
+const (
+	// PolicyCascade deletes the user's polls, with every vote on them, and their votes.
+	PolicyCascade = "cascade"
+	// PolicyAnonymize keeps polls and votes and scrubs the user row instead of deleting it.
+	PolicyAnonymize = "anonymize"
+	// PolicyTransfer hands the user's polls to -account-transfer-to and deletes their votes.
+	PolicyTransfer = "transfer"
+)
+
+// anonymizedPrefix starts the username of every anonymized account.
+const anonymizedPrefix = "deleted-"
+
+var (
+	deletionPolicy = flag.String("account-deletion", PolicyAnonymize, `what deleting an account does with its polls and votes: "cascade", "anonymize" or "transfer"`)
+	transferTo     = flag.String("account-transfer-to", "", "username that receives the polls of deleted accounts under -account-deletion=transfer")
+)
+
+var (
+	// ErrLastAdmin is returned when deleting the only remaining admin.
+	ErrLastAdmin = errors.New("the last admin can't be deleted")
+	// ErrTransferToSelf is returned when the transfer recipient deletes their own account.
+	ErrTransferToSelf = errors.New("the -account-transfer-to user can't be deleted")
+)
+
+// CheckPolicy validates -account-deletion and -account-transfer-to.
+func CheckPolicy() error {
+	switch *deletionPolicy {
+	case PolicyCascade, PolicyAnonymize:
+		return nil
+	case PolicyTransfer:
+		if *transferTo == "" {
+			return errors.New("-account-deletion=transfer requires -account-transfer-to")
+		}
+		return nil
+	}
+	return fmt.Errorf("-account-deletion: unknown policy %q", *deletionPolicy)
+}
+
+// Delete removes u's account, dealing with its polls and votes according to
+// -account-deletion. Sessions, tokens, identities and recovery codes go
+// with it under every policy.
+func Delete(ctx context.Context, client *ent.Client, u *ent.User) error {
+	// 1) Keep at least one admin around
+	if u.Role == user.RoleAdmin {
+		n, err := client.User.
+			Query().
+			Where(user.RoleEQ(user.RoleAdmin)).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if n <= 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	// 2) Apply the policy atomically
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	switch *deletionPolicy {
+	case PolicyCascade:
+		err = cascade(ctx, tx.Client(), u)
+	case PolicyTransfer:
+		err = transfer(ctx, tx.Client(), u)
+	default:
+		err = Anonymize(ctx, tx.Client(), u)
+	}
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func cascade(ctx context.Context, client *ent.Client, u *ent.User) error {
+	pollIDs, err := client.Poll.
+		Query().
+		Where(poll.CreatorIDEQ(u.ID)).
+		IDs(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Vote.
+		Delete().
+		Where(vote.Or(vote.UserIDEQ(u.ID), vote.PollIDIn(pollIDs...))).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.VoteHistory.
+		Delete().
+		Where(votehistory.Or(votehistory.UserIDEQ(u.ID), votehistory.PollIDIn(pollIDs...))).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.PollOption.
+		Delete().
+		Where(polloption.PollIDIn(pollIDs...)).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.Poll.
+		Delete().
+		Where(poll.IDIn(pollIDs...)).
+		Exec(ctx); err != nil {
+		return err
+	}
+	return client.User.DeleteOneID(u.ID).Exec(ctx)
+}
+
+func transfer(ctx context.Context, client *ent.Client, u *ent.User) error {
+	to, err := client.User.
+		Query().
+		Where(user.UsernameEQ(*transferTo)).
+		Only(ctx)
+	if err != nil {
+		return fmt.Errorf("loading -account-transfer-to user %q: %w", *transferTo, err)
+	}
+	if to.ID == u.ID {
+		return ErrTransferToSelf
+	}
+	if _, err := client.Poll.
+		Update().
+		Where(poll.CreatorIDEQ(u.ID)).
+		SetCreatorID(to.ID).
+		Save(ctx); err != nil {
+		return err
+	}
+	if _, err := client.Vote.
+		Delete().
+		Where(vote.UserIDEQ(u.ID)).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.VoteHistory.
+		Delete().
+		Where(votehistory.UserIDEQ(u.ID)).
+		Exec(ctx); err != nil {
+		return err
+	}
+	return client.User.DeleteOneID(u.ID).Exec(ctx)
+}
+
+// Anonymize keeps the row, so polls and votes stay attributed to a
+// placeholder, and strips everything that identifies or authenticates it,
+// freeing its username and email for new accounts. client may be a
+// transaction's.
+func Anonymize(ctx context.Context, client *ent.Client, u *ent.User) error {
+	if _, err := client.Session.Delete().Where(session.UserIDEQ(u.ID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.AccessToken.Delete().Where(accesstoken.UserIDEQ(u.ID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.Identity.Delete().Where(identity.UserIDEQ(u.ID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.UserToken.Delete().Where(usertoken.UserIDEQ(u.ID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.RecoveryCode.Delete().Where(recoverycode.UserIDEQ(u.ID)).Exec(ctx); err != nil {
+		return err
+	}
+	return client.User.
+		UpdateOneID(u.ID).
+		SetUsername(fmt.Sprintf("%s%d", anonymizedPrefix, u.ID)).
+		SetPasswordHash("").
+		ClearEmail().
+		SetEmailVerified(false).
+		ClearTotpSecret().
+		SetTotpEnabled(false).
+		SetTotpLastStep(0).
+		SetRole(user.RoleUser).
+		SetActive(false).
+		ClearExternalID().
+		ClearGroups().
+		ClearInvitedPolls().
+		SetDeletedAt(time.Now()).
+		Exec(ctx)
+}
+
+// Deactivate suspends a user, e.g. when they are deprovisioned: they can no
+// longer sign in, and their sessions, access tokens and pending email links
+// are revoked. Their polls and votes stay as they are. client may be a
+// transaction's, so callers can combine it with other changes.
+func Deactivate(ctx context.Context, client *ent.Client, userID int) error {
+	if _, err := client.Session.Delete().Where(session.UserIDEQ(userID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.AccessToken.Delete().Where(accesstoken.UserIDEQ(userID)).Exec(ctx); err != nil {
+		return err
+	}
+	if _, err := client.UserToken.Delete().Where(usertoken.UserIDEQ(userID)).Exec(ctx); err != nil {
+		return err
+	}
+	return client.User.
+		UpdateOneID(userID).
+		SetActive(false).
+		Exec(ctx)
+}
+
+// Reserved reports whether username has the form given to anonymized accounts.
+func Reserved(username string) bool {
+	return strings.HasPrefix(username, anonymizedPrefix)
+}
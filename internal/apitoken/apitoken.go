@@ -0,0 +1,116 @@
+// internal/apitoken/apitoken.go
+package apitoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/accesstoken"
+	"pollAppNew/internal/session"
+)
+
+// Prefix starts every personal access token, so bearer credentials can be
+// told apart from JWT access tokens without a database round trip.
+const Prefix = "pat_"
+
+// touchInterval throttles last_used_at writes, like sessions do for last_seen_at.
+const touchInterval = time.Minute
+
+var (
+	// ErrInvalid is returned when a token is unknown or expired.
+	ErrInvalid = errors.New("invalid or expired access token")
+	// ErrNotFound is returned when revoking a token the user doesn't own.
+	ErrNotFound = errors.New("access token not found")
+)
+
+// IsToken reports whether raw looks like a personal access token.
+func IsToken(raw string) bool {
+	return strings.HasPrefix(raw, Prefix)
+}
+
+// Create mints a named token for userID and returns its plaintext value,
+// which is shown to the user once and never stored.
+func Create(ctx context.Context, client *ent.Client, userID int, name string, scopes []string, expiresAt *time.Time) (string, *ent.AccessToken, error) {
+	secret, _, err := session.NewToken()
+	if err != nil {
+		return "", nil, err
+	}
+	raw := Prefix + secret
+	t, err := client.AccessToken.
+		Create().
+		SetName(name).
+		SetTokenHash(session.HashToken(raw)).
+		SetPrefix(raw[:len(Prefix)+6]).
+		SetScopes(scopes).
+		SetUserID(userID).
+		SetNillableExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return raw, t, nil
+}
+
+// Lookup resolves raw to its token, with the owning user loaded.
+func Lookup(ctx context.Context, client *ent.Client, raw string) (*ent.AccessToken, error) {
+	t, err := client.AccessToken.
+		Query().
+		Where(accesstoken.TokenHashEQ(session.HashToken(raw))).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalid
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if (t.ExpiresAt != nil && now.After(*t.ExpiresAt)) || t.Edges.User == nil {
+		return nil, ErrInvalid
+	}
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) > touchInterval {
+		if err := client.AccessToken.
+			UpdateOneID(t.ID).
+			SetLastUsedAt(now).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		t.LastUsedAt = &now
+	}
+	return t, nil
+}
+
+// ListForUser returns every token of userID, newest first.
+func ListForUser(ctx context.Context, client *ent.Client, userID int) ([]*ent.AccessToken, error) {
+	return client.AccessToken.
+		Query().
+		Where(accesstoken.UserIDEQ(userID)).
+		Order(ent.Desc(accesstoken.FieldCreatedAt)).
+		All(ctx)
+}
+
+// RevokeForUser deletes token id if it belongs to userID.
+func RevokeForUser(ctx context.Context, client *ent.Client, userID, id int) error {
+	n, err := client.AccessToken.
+		Delete().
+		Where(accesstoken.IDEQ(id), accesstoken.UserIDEQ(userID)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RevokeAll deletes every token of userID, e.g. when the account is banned.
+func RevokeAll(ctx context.Context, client *ent.Client, userID int) (int, error) {
+	return client.AccessToken.
+		Delete().
+		Where(accesstoken.UserIDEQ(userID)).
+		Exec(ctx)
+}
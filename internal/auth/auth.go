@@ -0,0 +1,205 @@
+// internal/auth/auth.go
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"pollAppNew/ent"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/mfa"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/token"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Method identifies how the caller authenticated.
+type Method int
+
+const (
+	// MethodNone means the request carried no credentials.
+	MethodNone Method = iota
+	// MethodSession means a browser session cookie.
+	MethodSession
+	// MethodBearer means an "Authorization: Bearer" access token.
+	MethodBearer
+	// MethodToken means a personal access token, also sent as a bearer credential.
+	MethodToken
+)
+
+// ErrInvalidCredentials marks credentials that were presented but are unknown, expired or forged.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type ctxKey struct{}
+
+// caller is what Middleware resolved for the current request.
+type caller struct {
+	method  Method
+	user    *ent.User
+	session *ent.Session
+	token   *ent.AccessToken
+	// mustEnroll is set when -require-2fa applies to a user without 2FA.
+	mustEnroll bool
+	// err is ErrInvalidCredentials for bad credentials, or a lookup failure.
+	err error
+}
+
+// Middleware resolves the caller once per request, from a bearer token if
+// the request has an Authorization header and from the session cookie
+// otherwise, and stores the result in the request context for the route
+// wrappers and handlers below. It never rejects a request on its own.
+func Middleware(client *ent.Client, keys *token.KeySet, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		c := &caller{}
+		if h := r.Header.Get("Authorization"); h != "" {
+			raw, ok := strings.CutPrefix(h, "Bearer ")
+			switch {
+			case !ok:
+				c.method, c.err = MethodBearer, ErrInvalidCredentials
+			case apitoken.IsToken(raw):
+				c.method = MethodToken
+				c.token, c.err = apitoken.Lookup(ctx, client, raw)
+			default:
+				c.method = MethodBearer
+				c.session, c.err = fromBearer(ctx, client, keys, raw)
+			}
+		} else if _, err := r.Cookie(session.CookieName); err == nil {
+			c.method = MethodSession
+			c.session, c.err = session.FromRequest(ctx, client, r)
+		}
+		if errors.Is(c.err, session.ErrInvalid) || errors.Is(c.err, apitoken.ErrInvalid) {
+			c.err = ErrInvalidCredentials
+		}
+		switch {
+		case c.session != nil:
+			c.user = c.session.Edges.User
+		case c.token != nil:
+			c.user = c.token.Edges.User
+		}
+		if c.user != nil && (c.user.BannedAt != nil || !c.user.Active) {
+			// banning and deactivating revoke credentials; this covers any
+			// still in flight
+			*c = caller{method: c.method, err: ErrInvalidCredentials}
+		}
+		if c.user != nil && !c.user.TotpEnabled {
+			c.mustEnroll, c.err = mfa.Required(ctx, client, c.user)
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, ctxKey{}, c)))
+	})
+}
+
+// fromBearer verifies an access token and loads the refresh session it was
+// issued under, so revoking that session also cuts off its access tokens.
+func fromBearer(ctx context.Context, client *ent.Client, keys *token.KeySet, raw string) (*ent.Session, error) {
+	claims, err := keys.Verify(raw)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	s, err := session.LookupID(ctx, client, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if uid, err := claims.UserID(); err != nil || uid != s.UserID {
+		return nil, ErrInvalidCredentials
+	}
+	return s, nil
+}
+
+// UserFromContext returns the authenticated user, or nil for anonymous callers.
+func UserFromContext(ctx context.Context) *ent.User {
+	return fromContext(ctx).user
+}
+
+// SessionFromContext returns the session the caller authenticated with, if
+// any. For bearer callers this is the refresh session behind the token; for
+// personal access token callers it is nil.
+func SessionFromContext(ctx context.Context) *ent.Session {
+	return fromContext(ctx).session
+}
+
+// MethodFromContext returns how the caller authenticated.
+func MethodFromContext(ctx context.Context) Method {
+	return fromContext(ctx).method
+}
+
+// fromContext never returns nil; without Middleware every caller is anonymous.
+func fromContext(ctx context.Context) *caller {
+	if c, ok := ctx.Value(ctxKey{}).(*caller); ok {
+		return c
+	}
+	return &caller{}
+}
+
+// RequireAuth rejects requests without a valid, live user with 401, and
+// users that -require-2fa obliges to enroll in 2FA but haven't with 403.
+func RequireAuth(h httprouter.Handle) httprouter.Handle {
+	return requireAuth(h, true)
+}
+
+// RequireAuthForMFASetup is RequireAuth for the routes a user needs in order
+// to enroll in 2FA, which therefore can't insist on it.
+func RequireAuthForMFASetup(h httprouter.Handle) httprouter.Handle {
+	return requireAuth(h, false)
+}
+
+func requireAuth(h httprouter.Handle, enforceMFA bool) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		c := fromContext(r.Context())
+		if !checkCaller(w, c) {
+			return
+		}
+		if c.user == nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if enforceMFA && c.mustEnroll {
+			http.Error(w, "two-factor authentication required; enroll at /me/2fa", http.StatusForbidden)
+			return
+		}
+		h(w, r, ps)
+	}
+}
+
+// OptionalAuth lets anonymous callers through. An invalid session cookie is
+// dropped (and cleared) rather than rejected; an invalid bearer token is
+// still rejected, since API clients need to know to refresh it.
+func OptionalAuth(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		c := fromContext(r.Context())
+		if c.err != nil {
+			if c.method != MethodSession || !errors.Is(c.err, ErrInvalidCredentials) {
+				checkCaller(w, c)
+				return
+			}
+			session.ClearCookie(w)
+			c = &caller{}
+			r = r.WithContext(context.WithValue(r.Context(), ctxKey{}, c))
+		}
+		h(w, r, ps)
+	}
+}
+
+// checkCaller writes an error response and reports false if resolving the
+// caller failed. Invalid credentials are answered with 401.
+func checkCaller(w http.ResponseWriter, c *caller) bool {
+	switch {
+	case c.err == nil:
+		return true
+	case errors.Is(c.err, ErrInvalidCredentials):
+		if c.method == MethodBearer || c.method == MethodToken {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		} else {
+			session.ClearCookie(w)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	default:
+		log.Printf("failed resolving caller: %v", c.err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// internal/auth/scope.go
+package auth
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Scope names a permission a personal access token can be granted.
+type Scope string
+
+const (
+	// ScopeNone marks routes personal access tokens may never call, such as
+	// session and token management.
+	ScopeNone Scope = ""
+
+	ScopePollsRead  Scope = "polls:read"
+	ScopePollsWrite Scope = "polls:write"
+	ScopeVotesWrite Scope = "votes:write"
+	ScopeUsersRead  Scope = "users:read"
+	// ScopeSCIM lets a provisioning client manage users and groups; the
+	// token's owner also needs a role allowed to provision.
+	ScopeSCIM Scope = "scim"
+)
+
+// Scopes lists every scope a token may be created with.
+var Scopes = []Scope{ScopePollsRead, ScopePollsWrite, ScopeVotesWrite, ScopeUsersRead, ScopeSCIM}
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s string) bool {
+	return slices.Contains(Scopes, Scope(s))
+}
+
+// Scoped rejects personal access token callers whose token lacks scope with
+// 403. Session and JWT callers act with the user's full authority and pass.
+func Scoped(scope Scope, h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		c := fromContext(r.Context())
+		if c.token != nil && (scope == ScopeNone || !slices.Contains(c.token.Scopes, string(scope))) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+string(scope)+`"`)
+			http.Error(w, "forbidden: token lacks required scope", http.StatusForbidden)
+			return
+		}
+		h(w, r, ps)
+	}
+}
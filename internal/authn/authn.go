@@ -0,0 +1,66 @@
+// internal/authn/authn.go
+package authn
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"pollAppNew/ent"
+)
+
+var backends = flag.String("auth-backends", "local", `comma-separated password backends tried in order: "local" and/or "ldap"`)
+
+// ErrInvalidCredentials is returned for an unknown user or a wrong password.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator checks a username and password and returns the local user
+// they belong to, provisioning it if the backend allows.
+type Authenticator interface {
+	Authenticate(ctx context.Context, username, password string) (*ent.User, error)
+}
+
+// Chain tries each authenticator in turn until one accepts the
+// credentials. Only ErrInvalidCredentials moves on to the next; any other
+// error stops the chain.
+type Chain []Authenticator
+
+// Authenticate implements Authenticator.
+func (c Chain) Authenticate(ctx context.Context, username, password string) (*ent.User, error) {
+	for _, a := range c {
+		u, err := a.Authenticate(ctx, username, password)
+		if !errors.Is(err, ErrInvalidCredentials) {
+			return u, err
+		}
+	}
+	return nil, ErrInvalidCredentials
+}
+
+// FromFlags builds the authenticator chain named by -auth-backends.
+func FromFlags(client *ent.Client) (Authenticator, error) {
+	var chain Chain
+	for _, name := range strings.Split(*backends, ",") {
+		switch strings.TrimSpace(name) {
+		case "local":
+			chain = append(chain, &Local{Client: client})
+		case "ldap":
+			l, err := NewLDAP(client, LDAPConfigFromFlags())
+			if err != nil {
+				return nil, err
+			}
+			chain = append(chain, l)
+		case "":
+		default:
+			return nil, fmt.Errorf("-auth-backends: unknown backend %q", name)
+		}
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("-auth-backends: no backend configured")
+	}
+	if len(chain) == 1 {
+		return chain[0], nil
+	}
+	return chain, nil
+}
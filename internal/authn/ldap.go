@@ -0,0 +1,247 @@
+// internal/authn/ldap.go
+package authn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/sso"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	ldapURL          = flag.String("ldap-url", "", "LDAP server URL, e.g. ldaps://ldap.example.com")
+	ldapStartTLS     = flag.Bool("ldap-start-tls", false, "upgrade ldap:// connections with StartTLS")
+	ldapCAFile       = flag.String("ldap-ca-file", "", "PEM file of the CA certificates trusted for ldaps:// and StartTLS; empty trusts the system roots")
+	ldapBindDN       = flag.String("ldap-bind-dn", "", "DN of the service account that searches for users; empty binds anonymously")
+	ldapBindPassword = flag.String("ldap-bind-password", "", "password of -ldap-bind-dn")
+	ldapBaseDN       = flag.String("ldap-base-dn", "", "base DN users are searched under")
+	ldapUserFilter   = flag.String("ldap-user-filter", "(uid=%s)", "filter finding a user by login name; %s is replaced by the escaped name")
+	ldapIDAttr       = flag.String("ldap-id-attr", "entryUUID", "attribute holding a stable user ID; the DN is used when it is missing")
+	ldapEmailAttr    = flag.String("ldap-email-attr", "mail", "attribute holding the user's email address")
+	ldapGroupAttr    = flag.String("ldap-group-attr", "memberOf", "attribute listing the DNs of the user's groups")
+	ldapGroupRoles   = flag.String("ldap-group-roles", "", `semicolon-separated role:groupDN pairs, e.g. "admin:cn=admins,ou=groups,dc=example,dc=com"; when set, every LDAP login re-derives the user's role from them`)
+	ldapLinkExisting = flag.Bool("ldap-link-existing", false, "link a first LDAP login to the local user with the same verified email instead of creating a new one")
+)
+
+// LDAPConfig configures the LDAP authenticator.
+type LDAPConfig struct {
+	URL          string
+	StartTLS     bool
+	CAFile       string
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	UserFilter   string
+	IDAttr       string
+	EmailAttr    string
+	GroupAttr    string
+	// GroupRoles maps lower-cased group DNs to the role their members get.
+	GroupRoles   map[string]user.Role
+	LinkExisting bool
+}
+
+// LDAPConfigFromFlags reads the -ldap-* flags.
+func LDAPConfigFromFlags() LDAPConfig {
+	return LDAPConfig{
+		URL:          *ldapURL,
+		StartTLS:     *ldapStartTLS,
+		CAFile:       *ldapCAFile,
+		BindDN:       *ldapBindDN,
+		BindPassword: *ldapBindPassword,
+		BaseDN:       *ldapBaseDN,
+		UserFilter:   *ldapUserFilter,
+		IDAttr:       *ldapIDAttr,
+		EmailAttr:    *ldapEmailAttr,
+		GroupAttr:    *ldapGroupAttr,
+		GroupRoles:   parseGroupRoles(*ldapGroupRoles),
+		LinkExisting: *ldapLinkExisting,
+	}
+}
+
+// parseGroupRoles parses -ldap-group-roles. Pairs without a colon are
+// skipped; unknown roles are rejected by NewLDAP.
+func parseGroupRoles(s string) map[string]user.Role {
+	m := map[string]user.Role{}
+	for _, pair := range strings.Split(s, ";") {
+		role, dn, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		m[strings.ToLower(strings.TrimSpace(dn))] = user.Role(strings.TrimSpace(role))
+	}
+	return m
+}
+
+// LDAP authenticates by binding as the user found under BaseDN and
+// provisions the matching local user just in time.
+type LDAP struct {
+	client *ent.Client
+	cfg    LDAPConfig
+	// roots verifies the server's certificate; nil uses the system roots.
+	roots *x509.CertPool
+}
+
+// NewLDAP validates cfg and returns an LDAP authenticator.
+func NewLDAP(client *ent.Client, cfg LDAPConfig) (*LDAP, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("ldap backend requires -ldap-url")
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Hostname() == "" {
+		return nil, fmt.Errorf("-ldap-url: %q is not an ldap:// or ldaps:// URL", cfg.URL)
+	}
+	if cfg.BaseDN == "" {
+		return nil, errors.New("ldap backend requires -ldap-base-dn")
+	}
+	if !strings.Contains(cfg.UserFilter, "%s") {
+		return nil, errors.New("-ldap-user-filter must contain %s")
+	}
+	for dn, role := range cfg.GroupRoles {
+		if err := user.RoleValidator(role); err != nil {
+			return nil, fmt.Errorf("-ldap-group-roles: %q for %q: %w", role, dn, err)
+		}
+	}
+	l := &LDAP{client: client, cfg: cfg}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("-ldap-ca-file: %w", err)
+		}
+		l.roots = x509.NewCertPool()
+		if !l.roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("-ldap-ca-file: no certificates in %s", cfg.CAFile)
+		}
+	}
+	return l, nil
+}
+
+// Authenticate implements Authenticator.
+func (l *LDAP) Authenticate(ctx context.Context, username, password string) (*ent.User, error) {
+	// an empty password would be an unauthenticated bind, which most servers accept
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	// 1) Connect and bind as the search account
+	conn, err := l.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	defer conn.Close()
+	if l.cfg.BindDN != "" {
+		if err := conn.Bind(l.cfg.BindDN, l.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap service bind: %w", err)
+		}
+	}
+
+	// 2) Find exactly one entry for the login name
+	res, err := conn.Search(ldap.NewSearchRequest(
+		l.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf(l.cfg.UserFilter, ldap.EscapeFilter(username)),
+		[]string{l.cfg.IDAttr, l.cfg.EmailAttr, l.cfg.GroupAttr},
+		nil,
+	))
+	// hitting the size limit means the name matched several entries; like no
+	// match at all, that is nobody to log in as
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) &&
+		!ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("ldap search: %w", err)
+	}
+	if res == nil || len(res.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	// 3) The user's own bind is the password check
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap user bind: %w", err)
+	}
+
+	// 4) Find or create the local user
+	subject := entry.GetAttributeValue(l.cfg.IDAttr)
+	if subject == "" {
+		subject = entry.DN
+	}
+	u, err := sso.Provision(ctx, l.client, "ldap", &sso.Claims{
+		Subject:       subject,
+		Username:      username,
+		Email:         entry.GetAttributeValue(l.cfg.EmailAttr),
+		EmailVerified: true, // the directory is authoritative for its own users
+	}, l.cfg.LinkExisting)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5) Keep the role in step with group membership
+	if len(l.cfg.GroupRoles) > 0 {
+		if role := l.role(entry.GetAttributeValues(l.cfg.GroupAttr)); role != u.Role {
+			if u, err = l.client.User.
+				UpdateOne(u).
+				SetRole(role).
+				Save(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return u, nil
+}
+
+// role returns the highest role any of groups maps to.
+func (l *LDAP) role(groups []string) user.Role {
+	best := user.RoleUser
+	for _, g := range groups {
+		switch l.cfg.GroupRoles[strings.ToLower(g)] {
+		case user.RoleAdmin:
+			return user.RoleAdmin
+		case user.RoleModerator:
+			best = user.RoleModerator
+		}
+	}
+	return best
+}
+
+// dial connects to the server, upgrading ldap:// with StartTLS when so
+// configured. Connecting and every request after it give up at ctx's
+// deadline.
+func (l *LDAP) dial(ctx context.Context) (*ldap.Conn, error) {
+	u, err := url.Parse(l.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), RootCAs: l.roots}
+	dialer := &net.Dialer{Timeout: ldap.DefaultTimeout}
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		dialer.Deadline = deadline
+	}
+	conn, err := ldap.DialURL(l.cfg.URL, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	if hasDeadline {
+		conn.SetTimeout(time.Until(deadline))
+	}
+	if l.cfg.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
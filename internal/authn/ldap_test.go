@@ -0,0 +1,409 @@
+package authn
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"pollAppNew/ent/enttest"
+	"pollAppNew/ent/identity"
+	"pollAppNew/ent/user"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/jimlambrt/gldap"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	serviceDN = "cn=search,dc=example,dc=com"
+	adminsDN  = "cn=Admins,ou=groups,dc=example,dc=com"
+	modsDN    = "cn=mods,ou=groups,dc=example,dc=com"
+)
+
+// directory is an in-process LDAP server answering simple binds, StartTLS
+// and (uid=…) searches from memory, the way a directory server would.
+// Searches need a bound connection, and with requireTLS so do binds.
+type directory struct {
+	mu         sync.Mutex
+	entries    []*ldap.Entry
+	passwords  map[string]string // by DN
+	requireTLS bool
+	ldaps      bool
+
+	cert    tls.Certificate
+	caFile  string
+	bound   map[int]bool // by connection
+	secured map[int]bool
+}
+
+// newDirectory returns a directory with a certificate for localhost, whose
+// PEM is in caFile.
+func newDirectory(t *testing.T) *directory {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return &directory{
+		passwords: map[string]string{},
+		cert:      tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		caFile:    caFile,
+		bound:     map[int]bool{},
+		secured:   map[int]bool{},
+	}
+}
+
+// serve listens on host and port, any free one if 0, over TLS from the
+// start with ldaps, and returns the port. The test is skipped if that
+// address can't be bound.
+func (d *directory) serve(t *testing.T, host string, port int, ldaps bool) int {
+	t.Helper()
+	if port == 0 {
+		l, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+		if err != nil {
+			t.Skipf("can't listen on %s: %v", host, err)
+		}
+		port = l.Addr().(*net.TCPAddr).Port
+		l.Close()
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+
+	mux, err := gldap.NewMux()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux.Bind(d.bind)
+	mux.Search(d.search)
+	mux.ExtendedOperation(d.startTLS, gldap.ExtendedOperationStartTLS)
+	s, err := gldap.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Router(mux)
+	var opts []gldap.Option
+	if ldaps {
+		opts = append(opts, gldap.WithTLSConfig(d.tlsConfig()))
+		d.ldaps = true
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- s.Run(addr, opts...) }()
+	t.Cleanup(func() { s.Stop() })
+
+	for !s.Ready() {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-errc:
+		t.Skipf("can't listen on %s: %v", addr, err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	return port
+}
+
+func (d *directory) tlsConfig() *tls.Config {
+	return &tls.Config{Certificates: []tls.Certificate{d.cert}}
+}
+
+func (d *directory) startTLS(w *gldap.ResponseWriter, r *gldap.Request) {
+	res := r.NewExtendedResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	res.SetResponseName(gldap.ExtendedOperationStartTLS)
+	w.Write(res)
+	if err := r.StartTLS(d.tlsConfig()); err != nil {
+		return
+	}
+	d.mu.Lock()
+	d.secured[r.ConnectionID()] = true
+	d.mu.Unlock()
+}
+
+func (d *directory) bind(w *gldap.ResponseWriter, r *gldap.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := r.NewBindResponse(gldap.WithResponseCode(gldap.ResultInvalidCredentials))
+	defer w.Write(res)
+	m, err := r.GetSimpleBindMessage()
+	if err != nil {
+		res.SetResultCode(gldap.ResultAuthMethodNotSupported)
+		return
+	}
+	if d.requireTLS && !d.ldaps && !d.secured[r.ConnectionID()] {
+		res.SetResultCode(gldap.ResultConfidentialityRequired)
+		return
+	}
+	d.bound[r.ConnectionID()] = false
+	if pw, ok := d.passwords[m.UserName]; ok && pw == string(m.Password) {
+		d.bound[r.ConnectionID()] = true
+		res.SetResultCode(gldap.ResultSuccess)
+	}
+}
+
+func (d *directory) search(w *gldap.ResponseWriter, r *gldap.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := r.NewSearchDoneResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	defer w.Write(res)
+	if !d.bound[r.ConnectionID()] {
+		res.SetResultCode(gldap.ResultInsufficientAccessRights)
+		return
+	}
+	m, err := r.GetSearchMessage()
+	if err != nil {
+		res.SetResultCode(gldap.ResultProtocolError)
+		return
+	}
+	sent := int64(0)
+	for _, e := range d.entries {
+		if m.Filter != "(uid="+e.GetAttributeValue("uid")+")" {
+			continue
+		}
+		if m.SizeLimit > 0 && sent == m.SizeLimit {
+			res.SetResultCode(gldap.ResultSizeLimitExceeded)
+			return
+		}
+		attrs := map[string][]string{}
+		for _, a := range e.Attributes {
+			attrs[a.Name] = a.Values
+		}
+		w.Write(r.NewSearchResponseEntry(e.DN, gldap.WithAttributes(attrs)))
+		sent++
+	}
+}
+
+// set replaces the attributes of the entry dn.
+func (d *directory) set(dn string, attrs map[string][]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, e := range d.entries {
+		if e.DN == dn {
+			d.entries[i] = ldap.NewEntry(dn, attrs)
+		}
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ldap?mode=memory&_fk=1")
+	defer client.Close()
+
+	dir := newDirectory(t)
+	dir.requireTLS = true
+	dir.entries = []*ldap.Entry{
+		ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+			"uid":       {"alice"},
+			"entryUUID": {"uuid-alice"},
+			"mail":      {"alice@example.com"},
+			"memberOf":  {"cn=admins,ou=groups,dc=example,dc=com"},
+		}),
+		ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+			"uid":       {"bob"},
+			"entryUUID": {"uuid-bob"},
+			"memberOf":  {modsDN},
+		}),
+		ldap.NewEntry("uid=dup,ou=people,dc=example,dc=com", map[string][]string{"uid": {"dup"}}),
+		ldap.NewEntry("uid=dup,ou=staff,dc=example,dc=com", map[string][]string{"uid": {"dup"}}),
+		ldap.NewEntry("uid=dup,ou=guests,dc=example,dc=com", map[string][]string{"uid": {"dup"}}),
+		ldap.NewEntry("uid=twin,ou=people,dc=example,dc=com", map[string][]string{"uid": {"twin"}}),
+		ldap.NewEntry("uid=twin,ou=staff,dc=example,dc=com", map[string][]string{"uid": {"twin"}}),
+	}
+	dir.passwords = map[string]string{
+		serviceDN:                               "service",
+		"uid=alice,ou=people,dc=example,dc=com": "alice-pw",
+		"uid=bob,ou=people,dc=example,dc=com":   "bob-pw",
+		"uid=dup,ou=people,dc=example,dc=com":   "dup-pw",
+		"uid=dup,ou=staff,dc=example,dc=com":    "dup-pw",
+		"uid=dup,ou=guests,dc=example,dc=com":   "dup-pw",
+		"uid=twin,ou=people,dc=example,dc=com":  "twin-pw",
+		"uid=twin,ou=staff,dc=example,dc=com":   "twin-pw",
+	}
+	l, err := NewLDAP(client, LDAPConfig{
+		URL:          "ldap://127.0.0.1:" + strconv.Itoa(dir.serve(t, "127.0.0.1", 0, false)),
+		StartTLS:     true,
+		CAFile:       dir.caFile,
+		BindDN:       serviceDN,
+		BindPassword: "service",
+		BaseDN:       "dc=example,dc=com",
+		UserFilter:   "(uid=%s)",
+		IDAttr:       "entryUUID",
+		EmailAttr:    "mail",
+		GroupAttr:    "memberOf",
+		GroupRoles:   parseGroupRoles("admin:" + adminsDN + "; moderator:" + modsDN),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, username, password string
+		wantErr                  error
+	}{
+		{"wrong password", "alice", "nope", ErrInvalidCredentials},
+		{"empty password", "alice", "", ErrInvalidCredentials},
+		{"unknown user", "carol", "alice-pw", ErrInvalidCredentials},
+		{"filter injection", "*", "alice-pw", ErrInvalidCredentials},
+		{"two entries match", "twin", "twin-pw", ErrInvalidCredentials},
+		{"more entries match than the search returns", "dup", "dup-pw", ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := l.Authenticate(ctx, tt.username, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("failed logins provisioned %d users", n)
+	}
+
+	// a first login provisions the user, with a role from their groups
+	u, err := l.Authenticate(ctx, "alice", "alice-pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Username != "alice" || u.Email == nil || *u.Email != "alice@example.com" || !u.EmailVerified || u.Role != user.RoleAdmin {
+		t.Fatalf("provisioned %+v", u)
+	}
+	if !client.Identity.Query().Where(identity.ProviderEQ("ldap"), identity.SubjectEQ("uuid-alice")).ExistX(ctx) {
+		t.Fatal("no ldap identity linked")
+	}
+	if bob, err := l.Authenticate(ctx, "bob", "bob-pw"); err != nil || bob.Role != user.RoleModerator {
+		t.Fatalf("bob: %+v, err %v", bob, err)
+	}
+
+	// later logins find the same user and follow group changes
+	dir.set("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"uid":       {"alice"},
+		"entryUUID": {"uuid-alice"},
+		"mail":      {"alice@example.com"},
+	})
+	again, err := l.Authenticate(ctx, "alice", "alice-pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != u.ID || again.Role != user.RoleUser {
+		t.Fatalf("second login: user %d role %s, want user %d role user", again.ID, again.Role, u.ID)
+	}
+	if n := client.User.Query().CountX(ctx); n != 2 {
+		t.Fatalf("%d users, want 2", n)
+	}
+
+	// a broken service account is an outage, not a wrong password
+	dir.mu.Lock()
+	dir.passwords[serviceDN] = "rotated"
+	dir.mu.Unlock()
+	if _, err := l.Authenticate(ctx, "alice", "alice-pw"); err == nil || errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("service bind failure: err = %v", err)
+	}
+}
+
+// Logins reach the directory through every URL form, and only over TLS the
+// server's certificate is trusted for.
+func TestLDAPDial(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ldapdial?mode=memory&_fk=1")
+	defer client.Close()
+
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name     string
+		host     string
+		port     int
+		ldaps    bool
+		scheme   string
+		startTLS bool
+		noCA     bool
+		ctx      context.Context
+		wantErr  bool
+	}{
+		{name: "StartTLS", host: "127.0.0.1", scheme: "ldap", startTLS: true},
+		{name: "upper-case scheme and a host name", host: "localhost", scheme: "LDAP", startTLS: true},
+		{name: "IPv6 host", host: "::1", scheme: "ldap", startTLS: true},
+		{name: "default port", host: "127.0.0.1", port: 389, scheme: "ldap", startTLS: true},
+		{name: "ldaps", host: "127.0.0.1", ldaps: true, scheme: "ldaps"},
+		{name: "untrusted certificate", host: "127.0.0.1", scheme: "ldap", startTLS: true, noCA: true, wantErr: true},
+		{name: "no StartTLS", host: "127.0.0.1", scheme: "ldap", wantErr: true},
+		{name: "deadline passed", host: "127.0.0.1", scheme: "ldap", startTLS: true, ctx: expired, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newDirectory(t)
+			dir.requireTLS = true
+			dir.entries = []*ldap.Entry{ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{"uid": {"alice"}})}
+			dir.passwords = map[string]string{serviceDN: "service", "uid=alice,ou=people,dc=example,dc=com": "alice-pw"}
+			port := dir.serve(t, tt.host, tt.port, tt.ldaps)
+			addr := net.JoinHostPort(tt.host, strconv.Itoa(port))
+			if tt.port == 389 {
+				addr = tt.host // the scheme's default port
+			}
+
+			cfg := LDAPConfig{
+				URL:          tt.scheme + "://" + addr,
+				StartTLS:     tt.startTLS,
+				CAFile:       dir.caFile,
+				BindDN:       serviceDN,
+				BindPassword: "service",
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(uid=%s)",
+			}
+			if tt.noCA {
+				cfg.CAFile = ""
+			}
+			l, err := NewLDAP(client, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := ctx
+			if tt.ctx != nil {
+				ctx = tt.ctx
+			}
+			_, err = l.Authenticate(ctx, "alice", "alice-pw")
+			if tt.wantErr {
+				if err == nil || errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("err = %v, want a connection error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNewLDAPRejectsBadURLs(t *testing.T) {
+	for _, u := range []string{"ldap.example.com", "ldap.example.com:389", "http://ldap.example.com", "ldap://"} {
+		if _, err := NewLDAP(nil, LDAPConfig{URL: u, BaseDN: "dc=example,dc=com", UserFilter: "(uid=%s)"}); err == nil {
+			t.Errorf("%q accepted", u)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// internal/authn/local.go
+package authn
+
+import (
+	"context"
+	"log"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/password"
+)
+
+// Local checks passwords against the hashes stored on users.
+type Local struct {
+	Client *ent.Client
+}
+
+// Authenticate looks up username and verifies pw against its stored hash in
+// constant time. Legacy plaintext or outdated-cost hashes are re-hashed.
+func (l *Local) Authenticate(ctx context.Context, username, pw string) (*ent.User, error) {
+	// 1) Fetch user by username
+	u, err := l.Client.User.
+		Query().
+		Where(user.UsernameEQ(username)).
+		Only(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			return nil, err
+		}
+		// spend as long as a real comparison so usernames can't be probed
+		password.VerifyDummy(pw)
+		return nil, ErrInvalidCredentials
+	}
+
+	// 2) Compare against the stored hash
+	ok, rehash := password.Verify(u.PasswordHash, pw)
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	// 3) Upgrade plaintext or outdated hashes; a failure here must not block login
+	if rehash {
+		if hash, err := password.Hash(pw); err != nil {
+			log.Printf("failed re-hashing password for user %d: %v", u.ID, err)
+		} else if err := l.Client.User.
+			UpdateOneID(u.ID).
+			SetPasswordHash(hash).
+			Exec(ctx); err != nil {
+			log.Printf("failed storing re-hashed password for user %d: %v", u.ID, err)
+		}
+	}
+	return u, nil
+}
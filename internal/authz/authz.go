@@ -0,0 +1,98 @@
+// internal/authz/authz.go
+package authz
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/auth"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+var admins = flag.String("admins", "", "comma-separated usernames given the admin role at startup")
+
+// Permission names something only some roles may do. Owners may always
+// act on their own polls; permissions extend that to everyone's.
+type Permission string
+
+const (
+	PollEditAny    Permission = "polls:edit-any"
+	PollDeleteAny  Permission = "polls:delete-any"
+	PollCloseAny   Permission = "polls:close-any"
+	UserBan        Permission = "users:ban"
+	UserAssignRole Permission = "users:assign-role"
+	AccountUnlock  Permission = "accounts:unlock"
+	UserProvision  Permission = "users:provision"
+)
+
+// matrix lists the permissions of each role.
+var matrix = map[user.Role][]Permission{
+	user.RoleUser:      nil,
+	user.RoleModerator: {PollDeleteAny, PollCloseAny, UserBan},
+	user.RoleAdmin:     {PollEditAny, PollDeleteAny, PollCloseAny, UserBan, UserAssignRole, AccountUnlock, UserProvision},
+}
+
+// rank orders roles for Outranks.
+var rank = map[user.Role]int{
+	user.RoleUser:      0,
+	user.RoleModerator: 1,
+	user.RoleAdmin:     2,
+}
+
+// Can reports whether u's role grants perm.
+func Can(u *ent.User, perm Permission) bool {
+	return u != nil && slices.Contains(matrix[u.Role], perm)
+}
+
+// CanManagePoll reports whether u may act on p: its owner always may,
+// anyone else needs perm.
+func CanManagePoll(u *ent.User, p *ent.Poll, perm Permission) bool {
+	return u != nil && (p.CreatorID == u.ID || Can(u, perm))
+}
+
+// Outranks reports whether a's role is strictly higher than b's, as needed
+// to ban b or change b's role.
+func Outranks(a, b *ent.User) bool {
+	return rank[a.Role] > rank[b.Role]
+}
+
+// Require is auth.RequireAuth for callers whose role grants perm; anyone
+// else gets 403.
+func Require(perm Permission, h httprouter.Handle) httprouter.Handle {
+	return auth.RequireAuth(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if !Can(auth.UserFromContext(r.Context()), perm) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		h(w, r, ps)
+	})
+}
+
+// Bootstrap gives the users named in -admins the admin role, so a fresh
+// install has someone to assign the other roles.
+func Bootstrap(ctx context.Context, client *ent.Client) error {
+	for _, name := range strings.Split(*admins, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		n, err := client.User.
+			Update().
+			Where(user.UsernameEQ(name), user.RoleNEQ(user.RoleAdmin)).
+			SetRole(user.RoleAdmin).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			log.Printf("granted admin role to %q", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// internal/authz/visibility.go
+package authz
+
+import (
+	"context"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/group"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/user"
+)
+
+// Listed restricts a poll query to the polls u may find by listing them:
+// public ones, private ones u is invited to and u's own. Roles allowed to
+// edit any poll skip it and list them all.
+func Listed(u *ent.User) predicate.Poll {
+	public := poll.VisibilityEQ(poll.VisibilityPublic)
+	if u == nil {
+		return public
+	}
+	return poll.Or(
+		public,
+		poll.And(poll.VisibilityEQ(poll.VisibilityPrivate), invited(u)),
+		poll.CreatorIDEQ(u.ID),
+	)
+}
+
+// CanViewPoll reports whether u may see p. Drafts are only visible to
+// their owner and to roles allowed to edit any poll, who could publish
+// them. Public and unlisted polls are open to anyone who has their ID;
+// private ones only to their owner, to invited users and members of
+// invited groups, and to roles allowed to edit any poll.
+func CanViewPoll(ctx context.Context, client *ent.Client, u *ent.User, p *ent.Poll) (bool, error) {
+	switch {
+	case CanManagePoll(u, p, PollEditAny):
+		return true, nil
+	case p.Status == poll.StatusDraft:
+		return false, nil
+	case p.Visibility != poll.VisibilityPrivate:
+		return true, nil
+	case u == nil:
+		return false, nil
+	}
+	return client.Poll.
+		Query().
+		Where(poll.IDEQ(p.ID), invited(u)).
+		Exist(ctx)
+}
+
+// invited matches polls that invite u directly or through a group.
+func invited(u *ent.User) predicate.Poll {
+	return poll.Or(
+		poll.HasInvitedUsersWith(user.IDEQ(u.ID)),
+		poll.HasInvitedGroupsWith(group.HasMembersWith(user.IDEQ(u.ID))),
+	)
+}
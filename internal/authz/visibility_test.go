@@ -0,0 +1,76 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/enttest"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestCanViewPoll(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:authz?mode=memory&_fk=1")
+	defer client.Close()
+	owner := client.User.Create().SetUsername("owner").SaveX(ctx)
+	guest := client.User.Create().SetUsername("guest").SaveX(ctx)
+	member := client.User.Create().SetUsername("member").SaveX(ctx)
+	stranger := client.User.Create().SetUsername("stranger").SaveX(ctx)
+	mod := client.User.Create().SetUsername("mod").SetRole(user.RoleModerator).SaveX(ctx)
+	admin := client.User.Create().SetUsername("admin").SetRole(user.RoleAdmin).SaveX(ctx)
+	g := client.Group.Create().SetDisplayName("g").AddMembers(member).SaveX(ctx)
+
+	newPoll := func(v poll.Visibility, s poll.Status) *ent.Poll {
+		return client.Poll.Create().
+			SetTitle("t").
+			SetCreatorID(owner.ID).
+			SetVisibility(v).
+			SetStatus(s).
+			AddInvitedUsers(guest).
+			AddInvitedGroups(g).
+			SaveX(ctx)
+	}
+	public := newPoll(poll.VisibilityPublic, poll.StatusOpen)
+	unlisted := newPoll(poll.VisibilityUnlisted, poll.StatusOpen)
+	private := newPoll(poll.VisibilityPrivate, poll.StatusOpen)
+	draft := newPoll(poll.VisibilityPublic, poll.StatusDraft)
+	privateDraft := newPoll(poll.VisibilityPrivate, poll.StatusDraft)
+
+	tests := []struct {
+		name string
+		u    *ent.User
+		p    *ent.Poll
+		want bool
+	}{
+		{"anonymous, public", nil, public, true},
+		{"anonymous, unlisted", nil, unlisted, true},
+		{"anonymous, private", nil, private, false},
+		{"anonymous, draft", nil, draft, false},
+		{"stranger, private", stranger, private, false},
+		{"invited user, private", guest, private, true},
+		{"invited group member, private", member, private, true},
+		{"moderator, private", mod, private, false},
+		{"admin, private", admin, private, true},
+		{"owner, private", owner, private, true},
+		{"stranger, draft", stranger, draft, false},
+		{"invited user, private draft", guest, privateDraft, false},
+		{"moderator, draft", mod, draft, false},
+		{"admin, private draft", admin, privateDraft, true},
+		{"owner, private draft", owner, privateDraft, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanViewPoll(ctx, client, tt.u, tt.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CanViewPoll = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
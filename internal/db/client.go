@@ -8,6 +8,7 @@ import (
 	"log"
 
 	"pollAppNew/ent"
+	"pollAppNew/ent/migrate"
 
 	entsql "entgo.io/ent/dialect/sql"
 
@@ -33,8 +34,9 @@ func NewClient() *ent.Client {
 	drv := entsql.OpenDB("postgres", dbConn)
 	client := ent.NewClient(ent.Driver(drv))
 
-	//3) Run your schema migrations
-	if err := client.Schema.Create(context.Background()); err != nil {
+	//3) Run your schema migrations; dropping indexes lets a unique index be
+	// replaced, as when votes went from one per poll to one per option
+	if err := client.Schema.Create(context.Background(), migrate.WithDropIndex(true)); err != nil {
 		log.Fatalf("failed running schema migration: %v", err)
 	}
 	return client
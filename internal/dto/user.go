@@ -0,0 +1,75 @@
+// internal/dto/user.go
+package dto
+
+import (
+	"time"
+
+	"pollAppNew/ent"
+)
+
+// Response types decouple what the API returns from the ent entities, so a
+// new column never reaches clients by accident. Handlers must encode these,
+// never *ent.User directly.
+
+// UserStats summarizes a user's activity.
+type UserStats struct {
+	PollsCreated int `json:"polls_created"`
+	VotesCast    int `json:"votes_cast"`
+}
+
+// PublicUser is what anyone may see about a user.
+type PublicUser struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	Stats     UserStats `json:"stats"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewPublicUser builds the public view of u.
+func NewPublicUser(u *ent.User, stats UserStats) PublicUser {
+	return PublicUser{
+		ID:        u.ID,
+		Username:  u.Username,
+		Role:      u.Role.String(),
+		Stats:     stats,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// Me is a user's view of their own account.
+type Me struct {
+	ID            int       `json:"id"`
+	Username      string    `json:"username"`
+	Email         *string   `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Role          string    `json:"role"`
+	HasPassword   bool      `json:"has_password"`
+	TOTPEnabled   bool      `json:"totp_enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewMe builds u's view of their own account.
+func NewMe(u *ent.User) Me {
+	return Me{
+		ID:            u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Role:          u.Role.String(),
+		HasPassword:   u.PasswordHash != "",
+		TOTPEnabled:   u.TotpEnabled,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+	}
+}
+
+// UserPage is one page of the user directory.
+type UserPage struct {
+	Users []PublicUser `json:"users"`
+	// NextCursor fetches the following page; empty on the last one.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/account"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/dto"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/mfa"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// GetMe returns the caller's profile.
+func GetMe() httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dto.NewMe(auth.UserFromContext(r.Context()))); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// UpdateMe changes the caller's username and/or email address. A new
+// address starts out unverified and gets a confirmation link; an empty one
+// removes the address.
+func UpdateMe(client *ent.Client, mailer mail.Mailer) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		// 1) Decode JSON body; absent fields are left alone
+		var req struct {
+			Username *string `json:"username"`
+			Email    *string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Validate and stage the changes
+		upd := client.User.UpdateOneID(u.ID)
+		if req.Username != nil {
+			if *req.Username == "" {
+				http.Error(w, "username must not be empty", http.StatusBadRequest)
+				return
+			}
+			if account.Reserved(*req.Username) {
+				http.Error(w, "username is reserved", http.StatusBadRequest)
+				return
+			}
+			upd.SetUsername(*req.Username)
+		}
+		emailChanged := false
+		if req.Email != nil {
+			e := normalizeEmail(*req.Email)
+			switch {
+			case e == "":
+				upd.ClearEmail().SetEmailVerified(false)
+			case !mail.ValidAddress(e):
+				http.Error(w, "invalid email address", http.StatusBadRequest)
+				return
+			case u.Email == nil || *u.Email != e:
+				upd.SetEmail(e).SetEmailVerified(false)
+				emailChanged = true
+			}
+		}
+
+		// 3) Save
+		u, err := upd.Save(ctx)
+		if err != nil {
+			if ent.IsConstraintError(err) {
+				http.Error(w, "username or email already taken", http.StatusConflict)
+				return
+			}
+			log.Printf("failed updating user: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Ask the user to confirm a new address
+		if emailChanged {
+			if err := sendVerification(ctx, client, mailer, u); err != nil {
+				log.Printf("failed sending verification email to user %d: %v", u.ID, err)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dto.NewMe(u)); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// ChangePassword sets a new password after checking the current one, and
+// signs out every other session and revokes every access token; wrong
+// current passwords back off like failed logins. Accounts created through
+// SSO have no current password and may set a first one.
+func ChangePassword(client *ent.Client, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+		if sso.SSOOnly() {
+			http.Error(w, "passwords are disabled; sign in with SSO", http.StatusForbidden)
+			return
+		}
+
+		// 1) Decode JSON body
+		var req struct {
+			CurrentPassword string `json:"current_password"`
+			NewPassword     string `json:"new_password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		if req.NewPassword == "" {
+			http.Error(w, "new_password required", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Check the current password
+		if !checkReauth(w, r, limits, u) {
+			return
+		}
+		if u.PasswordHash != "" {
+			if ok, _ := password.Verify(u.PasswordHash, req.CurrentPassword); !ok {
+				countReauth(ctx, limits, u, false)
+				http.Error(w, "current password is incorrect", http.StatusForbidden)
+				return
+			}
+		}
+		countReauth(ctx, limits, u, true)
+
+		// 3) Store the new one
+		hash, err := password.Hash(req.NewPassword)
+		if err != nil {
+			if errors.Is(err, password.ErrTooLong) {
+				http.Error(w, "password must be at most 72 bytes", http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed hashing password: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+		if err := tx.User.
+			UpdateOneID(u.ID).
+			SetPasswordHash(hash).
+			Exec(ctx); err != nil {
+			rollback()
+			log.Printf("failed updating password: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Sign out everywhere else, API clients included
+		if _, err := session.RevokeOthers(ctx, tx.Client(), u.ID, auth.SessionFromContext(ctx).ID); err != nil {
+			rollback()
+			log.Printf("failed revoking other sessions: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := apitoken.RevokeAll(ctx, tx.Client(), u.ID); err != nil {
+			rollback()
+			log.Printf("failed revoking access tokens: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteMe deletes the caller's account after re-checking their password
+// and, with 2FA on, a second factor; wrong ones back off like failed logins.
+// What happens to their polls and votes follows -account-deletion.
+func DeleteMe(client *ent.Client, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		// 1) Decode JSON body
+		var req struct {
+			Password string `json:"password"`
+			Code     string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Confirm it's really them
+		if !checkReauth(w, r, limits, u) {
+			return
+		}
+		if u.PasswordHash != "" {
+			if ok, _ := password.Verify(u.PasswordHash, req.Password); !ok {
+				countReauth(ctx, limits, u, false)
+				http.Error(w, "password is incorrect", http.StatusForbidden)
+				return
+			}
+		}
+		if u.TotpEnabled {
+			if err := mfa.Verify(ctx, client, u, req.Code); err != nil {
+				if errors.Is(err, mfa.ErrInvalidCode) {
+					countReauth(ctx, limits, u, false)
+					http.Error(w, "invalid two-factor code", http.StatusForbidden)
+					return
+				}
+				log.Printf("failed verifying two-factor code: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		countReauth(ctx, limits, u, true)
+
+		// 3) Delete the account
+		if err := account.Delete(ctx, client, u); err != nil {
+			switch {
+			case errors.Is(err, account.ErrLastAdmin), errors.Is(err, account.ErrTransferToSelf):
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				log.Printf("failed deleting user %d: %v", u.ID, err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		session.ClearCookie(w)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
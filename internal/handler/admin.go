@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// UnlockAccount clears the failed-login count and any lockout of a username.
+func UnlockAccount(limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		username := ps.ByName("username")
+		if err := limits.Account.Reset(r.Context(), username); err != nil {
+			log.Printf("failed unlocking %q: %v", username, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// targetUser loads the user named by the :id parameter and checks that the
+// caller outranks them. On failure it has already written the response and
+// returns nil.
+func targetUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client) *ent.User {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "invalid user id", http.StatusBadRequest)
+		return nil
+	}
+	u, err := client.User.Get(r.Context(), id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			http.Error(w, "user not found", http.StatusNotFound)
+			return nil
+		}
+		log.Printf("failed loading user %d: %v", id, err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil
+	}
+	if !authz.Outranks(auth.UserFromContext(r.Context()), u) {
+		http.Error(w, "forbidden: target role is not below yours", http.StatusForbidden)
+		return nil
+	}
+	return u
+}
+
+// BanUser bans a lower-ranked user and revokes all their sessions and
+// personal access tokens.
+func BanUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Load the target
+		u := targetUser(w, r, ps, client)
+		if u == nil {
+			return
+		}
+		if u.BannedAt != nil {
+			http.Error(w, "user is already banned", http.StatusConflict)
+			return
+		}
+
+		// 2) Begin transaction
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+
+		// 3) Mark banned and cut off every credential
+		if err := tx.User.
+			UpdateOneID(u.ID).
+			SetBannedAt(time.Now()).
+			Exec(ctx); err != nil {
+			rollback()
+			log.Printf("failed banning user %d: %v", u.ID, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := session.RevokeAll(ctx, tx.Client(), u.ID); err != nil {
+			rollback()
+			log.Printf("failed revoking sessions: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := apitoken.RevokeAll(ctx, tx.Client(), u.ID); err != nil {
+			rollback()
+			log.Printf("failed revoking access tokens: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Commit transaction
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// UnbanUser lifts a ban.
+func UnbanUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		u := targetUser(w, r, ps, client)
+		if u == nil {
+			return
+		}
+		if err := client.User.
+			UpdateOneID(u.ID).
+			ClearBannedAt().
+			Exec(r.Context()); err != nil {
+			log.Printf("failed unbanning user %d: %v", u.ID, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// SetUserRole assigns a role to a lower-ranked user. Only admins hold the
+// permission, so they can make other admins but never demote one.
+func SetUserRole(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Decode JSON body
+		var req struct {
+			Role string `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		role := user.Role(req.Role)
+		if err := user.RoleValidator(role); err != nil {
+			http.Error(w, `role must be "user", "moderator" or "admin"`, http.StatusBadRequest)
+			return
+		}
+
+		// 2) Load the target
+		u := targetUser(w, r, ps, client)
+		if u == nil {
+			return
+		}
+
+		// 3) Assign the role
+		if err := client.User.
+			UpdateOneID(u.ID).
+			SetRole(role).
+			Exec(ctx); err != nil {
+			log.Printf("failed setting role of user %d: %v", u.ID, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"user_id":  u.ID,
+			"username": u.Username,
+			"role":     role,
+		})
+	}
+}
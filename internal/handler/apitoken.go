@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/auth"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type accessTokenResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+func newAccessTokenResponse(t *ent.AccessToken) accessTokenResponse {
+	return accessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+	}
+}
+
+// CreateAccessToken mints a named personal access token for the caller. The
+// plaintext token is only ever returned in this response.
+func CreateAccessToken(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		// 1) Decode and validate request
+		var req struct {
+			Name      string     `json:"name"`
+			Scopes    []string   `json:"scopes"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if len(req.Scopes) == 0 {
+			http.Error(w, "at least one scope is required", http.StatusBadRequest)
+			return
+		}
+		for _, s := range req.Scopes {
+			if !auth.ValidScope(s) {
+				http.Error(w, "unknown scope "+strconv.Quote(s), http.StatusBadRequest)
+				return
+			}
+		}
+		if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+			http.Error(w, "expires_at must be in the future", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Create the token
+		raw, t, err := apitoken.Create(ctx, client, u.ID, req.Name, req.Scopes, req.ExpiresAt)
+		if err != nil {
+			log.Printf("failed creating access token: %v", err)
+			http.Error(w, "could not create access token", http.StatusInternalServerError)
+			return
+		}
+
+		// 3) Return it together with the one-time plaintext value
+		resp := struct {
+			accessTokenResponse
+			Token string `json:"token"`
+		}{
+			accessTokenResponse: newAccessTokenResponse(t),
+			Token:               raw,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// ListAccessTokens lists the caller's personal access tokens without their secrets.
+func ListAccessTokens(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		tokens, err := apitoken.ListForUser(ctx, client, auth.UserFromContext(ctx).ID)
+		if err != nil {
+			log.Printf("failed listing access tokens: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		resp := make([]accessTokenResponse, len(tokens))
+		for i, t := range tokens {
+			resp[i] = newAccessTokenResponse(t)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// RevokeAccessToken deletes one of the caller's personal access tokens.
+func RevokeAccessToken(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Parse token ID
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			http.Error(w, "invalid token id", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Delete it, but only if it belongs to the caller
+		if err := apitoken.RevokeForUser(ctx, client, auth.UserFromContext(ctx).ID, id); err != nil {
+			if errors.Is(err, apitoken.ErrNotFound) {
+				http.Error(w, "access token not found", http.StatusNotFound)
+				return
+			}
+			log.Printf("failed revoking access token %d: %v", id, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
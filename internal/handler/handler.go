@@ -1,15 +1,29 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"pollAppNew/ent"
 	"pollAppNew/ent/poll"
 	"pollAppNew/ent/polloption"
-	"pollAppNew/ent/user"
 	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+	"pollAppNew/internal/account"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/authn"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/lifecycle"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"sort"
 	"strconv"
 	"time"
 
@@ -17,15 +31,24 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
-// SignUp handles user registration (raw password, not for production).
-func SignUp(client *ent.Client) httprouter.Handle {
+// SignUp handles user registration, storing a bcrypt hash of the password.
+// An optional email address is stored unverified and a confirmation link is mailed to it.
+// Each client IP may only create a few accounts an hour.
+func SignUp(client *ent.Client, mailer mail.Mailer, limits *ratelimit.Limits) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		ctx := r.Context()
 
+		// 0) Local accounts are disabled in SSO-only mode
+		if sso.SSOOnly() {
+			http.Error(w, "signup is disabled; sign in with SSO", http.StatusForbidden)
+			return
+		}
+
 		// 1) Decode JSON body
 		var req struct {
 			Username string `json:"username"`
 			Password string `json:"password"`
+			Email    string `json:"email"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
@@ -35,32 +58,89 @@ func SignUp(client *ent.Client) httprouter.Handle {
 			http.Error(w, "username and password required", http.StatusBadRequest)
 			return
 		}
+		if account.Reserved(req.Username) {
+			http.Error(w, "username is reserved", http.StatusBadRequest)
+			return
+		}
+		var email *string
+		if req.Email != "" {
+			e := normalizeEmail(req.Email)
+			if !mail.ValidAddress(e) {
+				http.Error(w, "invalid email address", http.StatusBadRequest)
+				return
+			}
+			email = &e
+		}
 
-		// 2) Create user with raw password
+		// 2) Throttle account creation per client IP
+		ip := session.ClientIP(r)
+		if err := limits.Signup.Check(ctx, ip); err != nil {
+			writeLimitError(w, err)
+			return
+		}
+		if err := limits.Signup.Hit(ctx, ip); err != nil {
+			log.Printf("failed counting signup from %s: %v", ip, err)
+		}
+
+		// 3) Hash the password
+		hash, err := password.Hash(req.Password)
+		if err != nil {
+			if errors.Is(err, password.ErrTooLong) {
+				http.Error(w, "password must be at most 72 bytes", http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed hashing password: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Create user with the hashed password
 		u, err := client.User.
 			Create().
 			SetUsername(req.Username).
-			SetPasswordHash(req.Password).
+			SetPasswordHash(hash).
+			SetNillableEmail(email).
 			Save(ctx)
 		if err != nil {
+			if ent.IsConstraintError(err) {
+				http.Error(w, "username or email already taken", http.StatusConflict)
+				return
+			}
 			http.Error(w, "could not create user", http.StatusInternalServerError)
 			return
 		}
 
-		// 3) Return new user (ID and username only)
+		// 5) Ask the user to confirm their address; the account works meanwhile
+		if u.Email != nil {
+			if err := sendVerification(ctx, client, mailer, u); err != nil {
+				log.Printf("failed sending verification email to user %d: %v", u.ID, err)
+			}
+		}
+
+		// 6) Return new user (no password hash)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"user_id":  u.ID,
-			"username": u.Username,
+			"user_id":        u.ID,
+			"username":       u.Username,
+			"email":          u.Email,
+			"email_verified": u.EmailVerified,
+			"created_at":     u.CreatedAt,
 		})
 	}
 }
 
-// Login handles user authentication (raw passwords, not for production).
-func Login(client *ent.Client) httprouter.Handle {
+// Login handles user authentication against the configured backends. For
+// accounts with 2FA it returns an mfa_token to finish at /login/2fa instead
+// of starting a session. Repeated failures back off and lock the account.
+func Login(client *ent.Client, authenticator authn.Authenticator, limits *ratelimit.Limits) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		ctx := r.Context()
+		if sso.SSOOnly() {
+			http.Error(w, "password login is disabled; sign in with SSO", http.StatusForbidden)
+			return
+		}
+
 		var req struct {
 			Username string `json:"username"`
 			Password string `json:"password"`
@@ -70,58 +150,143 @@ func Login(client *ent.Client) httprouter.Handle {
 			return
 		}
 
-		// fetch user by username
-		u, err := client.User.
-			Query().
-			Where(user.UsernameEQ(req.Username)).
-			Only(ctx)
+		// verify username and password
+		u, err := checkLogin(r, authenticator, limits, req.Username, req.Password)
 		if err != nil {
-			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			writeLoginError(w, err)
 			return
 		}
 
-		// compare raw passwords
-		if req.Password != u.PasswordHash {
-			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		// with 2FA on, the password only earns a challenge for the second factor
+		if u.TotpEnabled {
+			challenge, _, err := session.CreateChallenge(ctx, client, u.ID, r)
+			if err != nil {
+				log.Printf("failed creating mfa challenge: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			writeMFAChallenge(w, http.StatusOK, challenge)
 			return
 		}
 
-		// set a cookie with the user ID
-		cookie := http.Cookie{
-			Name:     "user_id",
-			Value:    strconv.Itoa(u.ID),
-			Path:     "/",
-			HttpOnly: true,
-			Expires:  time.Now().Add(24 * time.Hour),
+		// start a server-side session and hand out its opaque token
+		token, sess, err := session.Create(ctx, client, u.ID, r)
+		if err != nil {
+			log.Printf("failed creating session: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
 		}
-		http.SetCookie(w, &cookie)
+		session.SetCookie(w, token, sess.ExpiresAt)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.Write([]byte(`{"message":"login successful"}`))
 	}
 }
 
-// CreatePoll handles creating a new poll, using the logged-in user from a cookie.
+var (
+	// errBanned is returned by checkLogin for a correct password on a banned account.
+	errBanned = errors.New("account banned")
+	// errDeactivated is returned by checkLogin for a correct password on a deprovisioned account.
+	errDeactivated = errors.New("account deactivated")
+)
+
+// checkLogin is authn behind the brute-force limits: while the
+// username or the client IP is backing off it fails with a
+// *ratelimit.LimitedError without checking the password, failures count
+// against both, and success clears the username's count.
+func checkLogin(r *http.Request, authenticator authn.Authenticator, limits *ratelimit.Limits, username, pw string) (*ent.User, error) {
+	ctx := r.Context()
+	ip := session.ClientIP(r)
+
+	// 1) Refuse early while backing off
+	if err := limits.Account.Check(ctx, username); err != nil {
+		return nil, err
+	}
+	if err := limits.IP.Check(ctx, ip); err != nil {
+		return nil, err
+	}
+
+	// 2) Verify and count the outcome
+	u, err := authenticator.Authenticate(ctx, username, pw)
+	switch {
+	case errors.Is(err, authn.ErrInvalidCredentials):
+		if err := errors.Join(limits.Account.Hit(ctx, username), limits.IP.Hit(ctx, ip)); err != nil {
+			log.Printf("failed counting login failure: %v", err)
+		}
+	case err == nil:
+		if err := limits.Account.Reset(ctx, username); err != nil {
+			log.Printf("failed resetting login failures of %q: %v", username, err)
+		}
+		if u.BannedAt != nil {
+			return nil, errBanned
+		}
+		if !u.Active {
+			return nil, errDeactivated
+		}
+	}
+	return u, err
+}
+
+// writeLoginError answers a failed checkLogin.
+func writeLoginError(w http.ResponseWriter, err error) {
+	var limited *ratelimit.LimitedError
+	switch {
+	case errors.As(err, &limited):
+		writeLimitError(w, err)
+	case errors.Is(err, authn.ErrInvalidCredentials):
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+	case errors.Is(err, errBanned):
+		http.Error(w, "account banned", http.StatusForbidden)
+	case errors.Is(err, errDeactivated):
+		http.Error(w, "account deactivated", http.StatusForbidden)
+	default:
+		log.Printf("failed checking credentials: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+// writeLimitError answers a throttled request with 429 and Retry-After, or
+// 500 if err is a failure of the limiter itself.
+func writeLimitError(w http.ResponseWriter, err error) {
+	var limited *ratelimit.LimitedError
+	if !errors.As(err, &limited) {
+		log.Printf("failed checking rate limit: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limited.RetryAfter.Seconds()))))
+	if limited.Locked {
+		http.Error(w, "account temporarily locked after too many failed logins", http.StatusTooManyRequests)
+		return
+	}
+	http.Error(w, "too many attempts, try again later", http.StatusTooManyRequests)
+}
+
+// CreatePoll handles creating a new poll, owned by the authenticated caller.
 func CreatePoll(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		ctx := r.Context()
 
-		// 0) Authenticate via cookie
-		c, err := r.Cookie("user_id")
-		if err != nil {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-		userID, err := strconv.Atoi(c.Value)
-		if err != nil {
-			http.Error(w, "invalid user_id cookie", http.StatusUnauthorized)
-			return
-		}
+		// 0) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		userID := auth.UserFromContext(ctx).ID
 
-		// 1) Decode request (no creator_id field)
+		// 1) Decode request (no creator_id field); everything but title
+		// and options is optional
 		var req struct {
-			Title   string   `json:"title"`
-			Options []string `json:"options"`
+			Title      string     `json:"title"`
+			Options    []string   `json:"options"`
+			Method     string     `json:"method"`
+			Draft      bool       `json:"draft"`
+			OpensAt    *time.Time `json:"opens_at"`
+			ClosesAt   *time.Time `json:"closes_at"`
+			MinChoices *int       `json:"min_choices"`
+			MaxChoices *int       `json:"max_choices"`
+			ScoreMin   *int       `json:"score_min"`
+			ScoreMax   *int       `json:"score_max"`
+			// AllowVoteChanges lets voters change or retract their ballot
+			AllowVoteChanges bool   `json:"allow_vote_changes"`
+			Visibility       string `json:"visibility"`
+			ResultsPolicy    string `json:"results_policy"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
@@ -135,6 +300,60 @@ func CreatePoll(client *ent.Client) httprouter.Handle {
 			http.Error(w, "at least two options are required", http.StatusBadRequest)
 			return
 		}
+		status, err := lifecycle.Initial(req.Draft, req.OpensAt, req.ClosesAt, time.Now())
+		if err != nil {
+			http.Error(w, "closes_at must be after opens_at and in the future", http.StatusBadRequest)
+			return
+		}
+		visibility := poll.VisibilityPublic
+		if req.Visibility != "" {
+			visibility = poll.Visibility(req.Visibility)
+			if poll.VisibilityValidator(visibility) != nil {
+				http.Error(w, "invalid visibility", http.StatusBadRequest)
+				return
+			}
+		}
+		resultsPolicy := poll.ResultsPolicyAlways
+		if req.ResultsPolicy != "" {
+			resultsPolicy = poll.ResultsPolicy(req.ResultsPolicy)
+			if poll.ResultsPolicyValidator(resultsPolicy) != nil {
+				http.Error(w, "invalid results_policy", http.StatusBadRequest)
+				return
+			}
+		}
+		method := poll.MethodPlurality
+		if req.Method != "" {
+			method = poll.Method(req.Method)
+			if poll.MethodValidator(method) != nil {
+				http.Error(w, "invalid method", http.StatusBadRequest)
+				return
+			}
+		}
+		// ranked ballots may rank every option unless told otherwise
+		defaultMax := 1
+		if ranked(method) {
+			defaultMax = len(req.Options)
+		}
+		minChoices, maxChoices, err := choiceBounds(req.MinChoices, req.MaxChoices, 1, defaultMax, len(req.Options))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// rated ballots score every option on the scale
+		scoreMin, scoreMax := 0, 5
+		if rated(method) {
+			minChoices, maxChoices = len(req.Options), len(req.Options)
+			if req.ScoreMin != nil {
+				scoreMin = *req.ScoreMin
+			}
+			if req.ScoreMax != nil {
+				scoreMax = *req.ScoreMax
+			}
+			if scoreMin >= scoreMax || scoreMax-scoreMin > maxScoreRange {
+				http.Error(w, fmt.Sprintf("score_max must exceed score_min by at most %d", maxScoreRange), http.StatusBadRequest)
+				return
+			}
+		}
 
 		// 2) Begin transaction
 		tx, err := client.Tx(ctx)
@@ -154,6 +373,17 @@ func CreatePoll(client *ent.Client) httprouter.Handle {
 			Create().
 			SetTitle(req.Title).
 			SetCreatorID(userID).
+			SetStatus(status).
+			SetMethod(method).
+			SetNillableOpensAt(req.OpensAt).
+			SetNillableClosesAt(req.ClosesAt).
+			SetMinChoices(minChoices).
+			SetMaxChoices(maxChoices).
+			SetScoreMin(scoreMin).
+			SetScoreMax(scoreMax).
+			SetAllowVoteChanges(req.AllowVoteChanges).
+			SetVisibility(visibility).
+			SetResultsPolicy(resultsPolicy).
 			Save(ctx)
 		if err != nil {
 			rollback()
@@ -189,26 +419,54 @@ func CreatePoll(client *ent.Client) httprouter.Handle {
 
 		// 6) Build and return response
 		type optionResp struct {
-			ID   int    `json:"id"`
-			Text string `json:"text"`
+			ID        int       `json:"id"`
+			Text      string    `json:"text"`
+			CreatedAt time.Time `json:"created_at"`
 		}
 		type pollResp struct {
-			ID        int          `json:"id"`
-			Title     string       `json:"title"`
-			CreatorID int          `json:"creator_id"`
-			Options   []optionResp `json:"options"`
+			ID               int                `json:"id"`
+			Title            string             `json:"title"`
+			CreatorID        int                `json:"creator_id"`
+			Status           poll.Status        `json:"status"`
+			Method           poll.Method        `json:"method"`
+			OpensAt          *time.Time         `json:"opens_at,omitempty"`
+			ClosesAt         *time.Time         `json:"closes_at,omitempty"`
+			MinChoices       int                `json:"min_choices"`
+			MaxChoices       int                `json:"max_choices"`
+			ScoreMin         *int               `json:"score_min,omitempty"`
+			ScoreMax         *int               `json:"score_max,omitempty"`
+			AllowVoteChanges bool               `json:"allow_vote_changes"`
+			Visibility       poll.Visibility    `json:"visibility"`
+			ResultsPolicy    poll.ResultsPolicy `json:"results_policy"`
+			CreatedAt        time.Time          `json:"created_at"`
+			UpdatedAt        time.Time          `json:"updated_at"`
+			Options          []optionResp       `json:"options"`
 		}
 
 		opts := make([]optionResp, len(createdOpts))
 		for i, o := range createdOpts {
-			opts[i] = optionResp{ID: o.ID, Text: o.Text}
+			opts[i] = optionResp{ID: o.ID, Text: o.Text, CreatedAt: o.CreatedAt}
 		}
 
 		resp := pollResp{
-			ID:        p.ID,
-			Title:     p.Title,
-			CreatorID: p.CreatorID,
-			Options:   opts,
+			ID:               p.ID,
+			Title:            p.Title,
+			CreatorID:        p.CreatorID,
+			Status:           p.Status,
+			Method:           p.Method,
+			OpensAt:          p.OpensAt,
+			ClosesAt:         p.ClosesAt,
+			MinChoices:       p.MinChoices,
+			MaxChoices:       p.MaxChoices,
+			AllowVoteChanges: p.AllowVoteChanges,
+			Visibility:       p.Visibility,
+			ResultsPolicy:    p.ResultsPolicy,
+			CreatedAt:        p.CreatedAt,
+			UpdatedAt:        p.UpdatedAt,
+			Options:          opts,
+		}
+		if rated(p.Method) {
+			resp.ScoreMin, resp.ScoreMax = &p.ScoreMin, &p.ScoreMax
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -219,6 +477,34 @@ func CreatePoll(client *ent.Client) httprouter.Handle {
 	}
 }
 
+// maxScoreRange caps the width of a rating scale.
+const maxScoreRange = 100
+
+// choiceBounds resolves the min_choices and max_choices of a poll with
+// nOptions options, keeping curMin and curMax where not given.
+func choiceBounds(minChoices, maxChoices *int, curMin, curMax, nOptions int) (int, int, error) {
+	lo, hi := curMin, curMax
+	if minChoices != nil {
+		lo = *minChoices
+		// raising the minimum alone raises the maximum with it
+		if maxChoices == nil && hi < lo {
+			hi = lo
+		}
+	}
+	if maxChoices != nil {
+		hi = *maxChoices
+	}
+	switch {
+	case lo < 1:
+		return 0, 0, errors.New("min_choices must be at least 1")
+	case hi < lo:
+		return 0, 0, errors.New("max_choices must not be below min_choices")
+	case hi > nOptions:
+		return 0, 0, errors.New("max_choices must not exceed the number of options")
+	}
+	return lo, hi, nil
+}
+
 // GetPoll retrieves a poll by ID.
 func GetPoll(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -249,34 +535,82 @@ func GetPoll(client *ent.Client) httprouter.Handle {
 			}
 			return
 		}
+		// drafts are only visible to those who could publish them, and
+		// private polls to those invited
+		u := auth.UserFromContext(ctx)
+		if !checkVisible(ctx, w, client, u, p, "poll not found", http.StatusNotFound) {
+			return
+		}
+		// counts are left out while the results policy hides them
+		showCounts, err := resultsVisible(ctx, client, u, p)
+		if err != nil {
+			log.Printf("failed checking results policy: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
 
 		// 3) Build response structs
 		type optionResponse struct {
-			ID    int    `json:"id"`
-			Text  string `json:"text"`
-			Votes int    `json:"votes"`
+			ID        int       `json:"id"`
+			Text      string    `json:"text"`
+			Votes     *int      `json:"votes,omitempty"`
+			CreatedAt time.Time `json:"created_at"`
 		}
 		type pollResponse struct {
-			ID        int              `json:"id"`
-			Title     string           `json:"title"`
-			CreatorID int              `json:"creator_id"`
-			Options   []optionResponse `json:"options"`
+			ID               int                `json:"id"`
+			Title            string             `json:"title"`
+			CreatorID        int                `json:"creator_id"`
+			Status           poll.Status        `json:"status"`
+			Method           poll.Method        `json:"method"`
+			OpensAt          *time.Time         `json:"opens_at,omitempty"`
+			ClosesAt         *time.Time         `json:"closes_at,omitempty"`
+			ClosedAt         *time.Time         `json:"closed_at,omitempty"`
+			MinChoices       int                `json:"min_choices"`
+			MaxChoices       int                `json:"max_choices"`
+			ScoreMin         *int               `json:"score_min,omitempty"`
+			ScoreMax         *int               `json:"score_max,omitempty"`
+			AllowVoteChanges bool               `json:"allow_vote_changes"`
+			Visibility       poll.Visibility    `json:"visibility"`
+			ResultsPolicy    poll.ResultsPolicy `json:"results_policy"`
+			ResultsHidden    bool               `json:"results_hidden,omitempty"`
+			CreatedAt        time.Time          `json:"created_at"`
+			UpdatedAt        time.Time          `json:"updated_at"`
+			Options          []optionResponse   `json:"options"`
 		}
 
 		opts := make([]optionResponse, len(p.Edges.Options))
 		for i, o := range p.Edges.Options {
 			opts[i] = optionResponse{
-				ID:    o.ID,
-				Text:  o.Text,
-				Votes: len(o.Edges.Votes),
+				ID:        o.ID,
+				Text:      o.Text,
+				CreatedAt: o.CreatedAt,
+			}
+			if showCounts {
+				opts[i].Votes = optionVotes(p, o.Edges.Votes)
 			}
 		}
 
 		resp := pollResponse{
-			ID:        p.ID,
-			Title:     p.Title,
-			CreatorID: p.CreatorID,
-			Options:   opts,
+			ID:               p.ID,
+			Title:            p.Title,
+			CreatorID:        p.CreatorID,
+			Status:           lifecycle.Status(p, time.Now()),
+			Method:           p.Method,
+			OpensAt:          p.OpensAt,
+			ClosesAt:         p.ClosesAt,
+			ClosedAt:         p.ClosedAt,
+			MinChoices:       p.MinChoices,
+			MaxChoices:       p.MaxChoices,
+			AllowVoteChanges: p.AllowVoteChanges,
+			Visibility:       p.Visibility,
+			ResultsPolicy:    p.ResultsPolicy,
+			ResultsHidden:    !showCounts,
+			CreatedAt:        p.CreatedAt,
+			UpdatedAt:        p.UpdatedAt,
+			Options:          opts,
+		}
+		if rated(p.Method) {
+			resp.ScoreMin, resp.ScoreMax = &p.ScoreMin, &p.ScoreMax
 		}
 
 		// 4) JSON-encode and return
@@ -288,14 +622,42 @@ func GetPoll(client *ent.Client) httprouter.Handle {
 	}
 }
 
-// ListPolls retrieves all polls
+// ListPolls retrieves the polls the caller may list, optionally only those
+// with ?status= as of now and ordered by ?sort=created_at, or -created_at
+// for newest first. Unlisted polls are left out, except for their creator.
 func ListPolls(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		ctx := r.Context()
-
-		// 1. Query all polls, eager‐loading their options:
-		polls, err := client.Poll.
-			Query().
+		now := time.Now()
+
+		// 1. Query all polls the caller may list, eager‐loading their
+		// options; drafts are listed only for their creator
+		q := client.Poll.Query()
+		if u := auth.UserFromContext(ctx); !authz.Can(u, authz.PollEditAny) {
+			notDraft := poll.StatusNEQ(poll.StatusDraft)
+			if u != nil {
+				notDraft = poll.Or(notDraft, poll.CreatorIDEQ(u.ID))
+			}
+			q = q.Where(notDraft, authz.Listed(u))
+		}
+		if s := r.URL.Query().Get("status"); s != "" {
+			if poll.StatusValidator(poll.Status(s)) != nil {
+				http.Error(w, "invalid status", http.StatusBadRequest)
+				return
+			}
+			q = q.Where(lifecycle.StatusIs(poll.Status(s), now))
+		}
+		switch r.URL.Query().Get("sort") {
+		case "":
+		case "created_at":
+			q = q.Order(ent.Asc(poll.FieldCreatedAt), ent.Asc(poll.FieldID))
+		case "-created_at":
+			q = q.Order(ent.Desc(poll.FieldCreatedAt), ent.Desc(poll.FieldID))
+		default:
+			http.Error(w, "invalid sort, expected created_at or -created_at", http.StatusBadRequest)
+			return
+		}
+		polls, err := q.
 			WithOptions().
 			All(ctx)
 		if err != nil {
@@ -306,7 +668,10 @@ func ListPolls(client *ent.Client) httprouter.Handle {
 			return
 		}
 
-		// 2. Serialize to JSON and send:
+		// 2. Report the status as of now, then serialize to JSON and send:
+		for _, p := range polls {
+			p.Status = lifecycle.Status(p, now)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(polls); err != nil {
 			http.Error(w, "failed encoding response", http.StatusInternalServerError)
@@ -315,66 +680,38 @@ func ListPolls(client *ent.Client) httprouter.Handle {
 	}
 }
 
-// Vote handles voting on an option in a poll and returns updated results.
+// Vote casts the caller's ballot on a poll and returns updated results.
+// See ballotRequest for the body.
 func Vote(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		ctx := r.Context()
 
-		// 0) Authenticate via cookie
-		c, err := r.Cookie("user_id")
-		if err != nil {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-		userID, err := strconv.Atoi(c.Value)
-		if err != nil {
-			http.Error(w, "invalid user_id cookie", http.StatusUnauthorized)
-			return
-		}
-
-		// 0b) Verify user still exists
-		exists, err := client.User.
-			Query().
-			Where(user.IDEQ(userID)).
-			Exist(ctx)
-		if err != nil {
-			log.Printf("error checking user existence: %v", err)
-			http.Error(w, "internal server error", http.StatusInternalServerError)
-			return
-		}
-		if !exists {
-			// clear bad cookie
-			http.SetCookie(w, &http.Cookie{
-				Name:     "user_id",
-				Value:    "",
-				Path:     "/",
-				HttpOnly: true,
-				MaxAge:   -1,
-			})
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
+		// 0) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		userID := auth.UserFromContext(ctx).ID
 
-		// 1) Parse poll ID from path
-		pollID, err := strconv.Atoi(ps.ByName("id"))
-		if err != nil {
-			http.Error(w, "invalid poll id", http.StatusBadRequest)
+		// 1) Only open polls take votes
+		p := votablePoll(w, r, ps, client)
+		if p == nil {
 			return
 		}
 
 		// 2) Decode request body
-		var req struct {
-			OptionID int `json:"option_id"`
-		}
+		var req ballotRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
 			return
 		}
 
-		// 3) Prevent duplicate vote
+		// 3) Validate the ballot against the poll
+		optionIDs, ok := checkBallot(ctx, w, client, p, &req)
+		if !ok {
+			return
+		}
+
+		// 4) Prevent duplicate vote
 		voted, err := client.Vote.
 			Query().
-			Where(vote.UserIDEQ(userID), vote.PollIDEQ(pollID)).
+			Where(vote.UserIDEQ(userID), vote.PollIDEQ(p.ID)).
 			Exist(ctx)
 		if err != nil {
 			log.Printf("error checking existing vote: %v", err)
@@ -386,13 +723,21 @@ func Vote(client *ent.Client) httprouter.Handle {
 			return
 		}
 
-		// 4) Insert the vote
-		if _, err = client.Vote.
-			Create().
-			SetUserID(userID).
-			SetPollID(pollID).
-			SetOptionID(req.OptionID).
-			Save(ctx); err != nil {
+		// 5) Insert the ballot, all of it or nothing, and record it
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+		votes, err := storeBallot(ctx, tx, userID, p, optionIDs, req.Scores, votehistory.ActionCast)
+		if err != nil {
+			rollback()
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
 				switch pgErr.ConstraintName {
@@ -407,55 +752,104 @@ func Vote(client *ent.Client) httprouter.Handle {
 				}
 				return
 			}
+			// a concurrent ballot from the same voter won the unique index
+			if ent.IsConstraintError(err) {
+				http.Error(w, "user has already voted on this poll", http.StatusConflict)
+				return
+			}
 			log.Printf("failed creating vote: %v", err)
 			http.Error(w, "could not cast vote", http.StatusInternalServerError)
 			return
 		}
-
-		// 5) Load updated results
-		opts, err := client.PollOption.
-			Query().
-			Where(polloption.PollIDEQ(pollID)).
-			WithVotes().
-			All(ctx)
-		if err != nil {
-			log.Printf("error querying results: %v", err)
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
 			http.Error(w, "internal server error", http.StatusInternalServerError)
 			return
 		}
 
-		// 6) Build results response
-		type result struct {
-			OptionID int    `json:"option_id"`
-			Text     string `json:"text"`
-			Votes    int    `json:"votes"`
-		}
-		results := make([]result, len(opts))
-		for i, o := range opts {
-			results[i] = result{
-				OptionID: o.ID,
-				Text:     o.Text,
-				Votes:    len(o.Edges.Votes),
-			}
+		// 6) Return the ballot with updated results
+		writeBallot(ctx, w, client, p, http.StatusCreated, votes)
+	}
+}
+
+// ballotRequest is the body of a vote. On plurality polls option_ids
+// selects between min_choices and max_choices options, and a lone
+// option_id is also accepted; on ranked polls option_ids is the ranking,
+// most preferred first. Score and star ballots instead rate every option
+// in scores, keyed by option ID.
+type ballotRequest struct {
+	OptionID  int         `json:"option_id"`
+	OptionIDs []int       `json:"option_ids"`
+	Scores    map[int]int `json:"scores"`
+}
+
+// checkBallot validates req as a ballot on p and returns the options it
+// names in ballot order. On failure it has already written the response.
+func checkBallot(ctx context.Context, w http.ResponseWriter, client *ent.Client, p *ent.Poll, req *ballotRequest) ([]int, bool) {
+	if !rated(p.Method) {
+		optionIDs := req.OptionIDs
+		if optionIDs == nil && req.OptionID != 0 {
+			optionIDs = []int{req.OptionID}
 		}
+		return optionIDs, checkSelection(ctx, w, client, p, optionIDs)
+	}
 
-		resp := struct {
-			PollID  int      `json:"poll_id"`
-			Results []result `json:"results"`
-		}{
-			PollID:  pollID,
-			Results: results,
+	// rated polls keep min_choices and max_choices at the number of options
+	if len(req.Scores) != p.MaxChoices {
+		http.Error(w, "scores must rate every option", http.StatusBadRequest)
+		return nil, false
+	}
+	optionIDs := make([]int, 0, len(req.Scores))
+	for id, score := range req.Scores {
+		if score < p.ScoreMin || score > p.ScoreMax {
+			http.Error(w, fmt.Sprintf("scores must be between %d and %d", p.ScoreMin, p.ScoreMax), http.StatusBadRequest)
+			return nil, false
 		}
+		optionIDs = append(optionIDs, id)
+	}
+	sort.Ints(optionIDs)
+	return optionIDs, checkSelection(ctx, w, client, p, optionIDs)
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			log.Printf("failed encoding response: %v", err)
+// checkSelection reports whether optionIDs is a valid ballot for p: distinct
+// options of p, between min_choices and max_choices of them. Otherwise it
+// has already written the response.
+func checkSelection(ctx context.Context, w http.ResponseWriter, client *ent.Client, p *ent.Poll, optionIDs []int) bool {
+	if len(optionIDs) < p.MinChoices || len(optionIDs) > p.MaxChoices {
+		if p.MinChoices == p.MaxChoices {
+			http.Error(w, fmt.Sprintf("select exactly %d option(s)", p.MinChoices), http.StatusBadRequest)
+		} else {
+			http.Error(w, fmt.Sprintf("select between %d and %d options", p.MinChoices, p.MaxChoices), http.StatusBadRequest)
+		}
+		return false
+	}
+	seen := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if seen[id] {
+			http.Error(w, "each option may be selected once", http.StatusBadRequest)
+			return false
 		}
+		seen[id] = true
+	}
+	n, err := client.PollOption.
+		Query().
+		Where(polloption.IDIn(optionIDs...), polloption.PollIDEQ(p.ID)).
+		Count(ctx)
+	if err != nil {
+		log.Printf("error checking options: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return false
 	}
+	if n != len(optionIDs) {
+		http.Error(w, "invalid option id", http.StatusBadRequest)
+		return false
+	}
+	return true
 }
 
-// GetResults retrieves vote counts for a poll.
+// GetResults retrieves vote counts for a poll. While its results policy
+// hides them from the caller only the options are listed.
 func GetResults(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		ctx := r.Context()
@@ -465,58 +859,34 @@ func GetResults(client *ent.Client) httprouter.Handle {
 			http.Error(w, "invalid poll id", http.StatusBadRequest)
 			return
 		}
-		// 2) Ensure poll exists
-		exists, err := client.Poll.
-			Query().
-			Where(poll.IDEQ(pollID)).
-			Exist(ctx)
+		// 2) Load the poll, whose method decides the count
+		p, err := client.Poll.Get(ctx, pollID)
 		if err != nil {
-			log.Printf("error checking poll existence: %v", err)
-			http.Error(w, "internal server error", http.StatusInternalServerError)
+			if ent.IsNotFound(err) {
+				http.Error(w, "poll not found", http.StatusNotFound)
+			} else {
+				log.Printf("error loading poll: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
 			return
 		}
-		if !exists {
-			http.Error(w, "poll not found", http.StatusNotFound)
+		if !checkVisible(ctx, w, client, auth.UserFromContext(ctx), p, "poll not found", http.StatusNotFound) {
 			return
 		}
-		// 3) Load options + their votes
-		opts, err := client.PollOption.
-			Query().
-			Where(polloption.PollIDEQ(pollID)).
-			WithVotes().
-			All(ctx)
+		// 3) Tally the ballots, redacted if the results policy hides them
+		resp, err := visibleResults(ctx, client, auth.UserFromContext(ctx), p)
 		if err != nil {
-			log.Printf("error querying options: %v", err)
+			log.Printf("error querying results: %v", err)
 			http.Error(w, "internal server error", http.StatusInternalServerError)
 			return
 		}
-		// 4) Build response
-		type result struct {
-			OptionID int    `json:"option_id"`
-			Text     string `json:"text"`
-			Votes    int    `json:"votes"`
-		}
-		results := make([]result, len(opts))
-		for i, o := range opts {
-			results[i] = result{
-				OptionID: o.ID,
-				Text:     o.Text,
-				Votes:    len(o.Edges.Votes),
-			}
-		}
-		resp := struct {
-			PollID  int      `json:"poll_id"`
-			Results []result `json:"results"`
-		}{
-			PollID:  pollID,
-			Results: results,
-		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(resp)
 	}
 }
 
-// GetVoters retrieves users who voted for a specific option.
+// GetVoters retrieves users who voted for a specific option, if the poll's
+// results policy lets the caller see its counts.
 func GetVoters(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		ctx := r.Context()
@@ -526,18 +896,34 @@ func GetVoters(client *ent.Client) httprouter.Handle {
 			http.Error(w, "invalid option id", http.StatusBadRequest)
 			return
 		}
-		// 2) Ensure option exists
-		exists, err := client.PollOption.
+		// 2) Ensure option exists on a poll the caller may see
+		o, err := client.PollOption.
 			Query().
 			Where(polloption.IDEQ(optID)).
-			Exist(ctx)
+			WithPoll().
+			Only(ctx)
 		if err != nil {
-			log.Printf("error checking option existence: %v", err)
+			if ent.IsNotFound(err) {
+				http.Error(w, "option not found", http.StatusNotFound)
+			} else {
+				log.Printf("error checking option existence: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+		u := auth.UserFromContext(ctx)
+		if !checkVisible(ctx, w, client, u, o.Edges.Poll, "option not found", http.StatusNotFound) {
+			return
+		}
+		// who voted for an option gives its count away
+		showCounts, err := resultsVisible(ctx, client, u, o.Edges.Poll)
+		if err != nil {
+			log.Printf("failed checking results policy: %v", err)
 			http.Error(w, "internal server error", http.StatusInternalServerError)
 			return
 		}
-		if !exists {
-			http.Error(w, "option not found", http.StatusNotFound)
+		if !showCounts {
+			http.Error(w, "results are hidden by the poll's results policy", http.StatusForbidden)
 			return
 		}
 		// 3) Load all votes for that option, with user edges
@@ -553,8 +939,9 @@ func GetVoters(client *ent.Client) httprouter.Handle {
 		}
 		// 4) Build response
 		type voter struct {
-			UserID   int    `json:"user_id"`
-			Username string `json:"username"`
+			UserID   int       `json:"user_id"`
+			Username string    `json:"username"`
+			VotedAt  time.Time `json:"voted_at"`
 		}
 		voters := make([]voter, len(votes))
 		for i, v := range votes {
@@ -562,6 +949,7 @@ func GetVoters(client *ent.Client) httprouter.Handle {
 			voters[i] = voter{
 				UserID:   u.ID,
 				Username: u.Username,
+				VotedAt:  v.CreatedAt,
 			}
 		}
 		resp := struct {
@@ -576,41 +964,22 @@ func GetVoters(client *ent.Client) httprouter.Handle {
 	}
 }
 
-// ListUsers retrieves all registered users
-func ListUsers(client *ent.Client) httprouter.Handle {
+// Logout revokes the caller's session server-side and clears its cookie.
+func Logout(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		ctx := r.Context()
 
-		users, err := client.User.
-			Query().
-			All(ctx)
-		if err != nil {
-			http.Error(w, "failed querying users", http.StatusInternalServerError)
-			log.Printf("ERROR querying users: %v\n", err)
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(users); err != nil {
-			http.Error(w, "failed encoding response", http.StatusInternalServerError)
-			return
+		// Revoke the session, if any; an unknown token is already logged out
+		if sess := auth.SessionFromContext(ctx); sess != nil {
+			if err := session.Revoke(ctx, client, sess.ID); err != nil && !ent.IsNotFound(err) {
+				log.Printf("failed revoking session %d: %v", sess.ID, err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
 		}
-	}
-}
 
-// Logout handles user logout by clearing the "user_id" cookie.
-func Logout(client *ent.Client) httprouter.Handle {
-	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		// Overwrite the cookie with an expired one to remove it from the browser
-		cookie := &http.Cookie{
-			Name:     "user_id",
-			Value:    "",
-			Path:     "/",
-			HttpOnly: true,
-			Expires:  time.Unix(0, 0),
-			MaxAge:   -1,
-		}
-		http.SetCookie(w, cookie)
+		session.ClearCookie(w)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -618,23 +987,15 @@ func Logout(client *ent.Client) httprouter.Handle {
 	}
 }
 
-// UpdatePoll allows the creator to replace a poll’s options.
-// This will also delete all existing votes on that poll.
+// UpdatePoll allows the creator, or a role allowed to edit any poll, to
+// replace a poll’s options. This will also delete all existing votes on that poll,
+// which the vote history records as retracted.
 func UpdatePoll(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		ctx := r.Context()
 
-		// 1) Authenticate via cookie
-		c, err := r.Cookie("user_id")
-		if err != nil {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-		userID, err := strconv.Atoi(c.Value)
-		if err != nil {
-			http.Error(w, "invalid user_id cookie", http.StatusUnauthorized)
-			return
-		}
+		// 1) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		u := auth.UserFromContext(ctx)
 
 		// 2) Parse poll ID
 		pollID, err := strconv.Atoi(ps.ByName("id"))
@@ -657,14 +1018,15 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 			}
 			return
 		}
-		if p.CreatorID != userID {
-			http.Error(w, "forbidden", http.StatusForbidden)
+		if !checkManaged(ctx, w, client, u, p, authz.PollEditAny) {
 			return
 		}
 
-		// 4) Decode new options
+		// 4) Decode new options, and optionally new selection bounds
 		var req struct {
-			Options []string `json:"options"`
+			Options    []string `json:"options"`
+			MinChoices *int     `json:"min_choices"`
+			MaxChoices *int     `json:"max_choices"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
@@ -674,6 +1036,19 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 			http.Error(w, "at least two options are required", http.StatusBadRequest)
 			return
 		}
+		// ranked ballots keep ranking every option when their number changes
+		curMax := p.MaxChoices
+		if ranked(p.Method) {
+			curMax = len(req.Options)
+		}
+		minChoices, maxChoices, err := choiceBounds(req.MinChoices, req.MaxChoices, p.MinChoices, curMax, len(req.Options))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if rated(p.Method) {
+			minChoices, maxChoices = len(req.Options), len(req.Options)
+		}
 
 		// 5) Begin transaction
 		tx, err := client.Tx(ctx)
@@ -688,16 +1063,22 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 			}
 		}
 
-		// 6) Delete all votes for this poll
-		if _, err := tx.Vote.
-			Delete().
-			Where(vote.PollIDEQ(pollID)).
-			Exec(ctx); err != nil {
+		// 6) Delete all votes for this poll, recording each ballot as retracted
+		voters, err := deleteBallots(ctx, tx, pollID)
+		if err != nil {
 			rollback()
 			log.Printf("failed deleting votes: %v", err)
 			http.Error(w, "could not clear votes", http.StatusInternalServerError)
 			return
 		}
+		for _, voterID := range voters {
+			if err := recordBallot(ctx, tx, voterID, pollID, votehistory.ActionRetract, []int{}, nil); err != nil {
+				rollback()
+				log.Printf("failed recording retraction: %v", err)
+				http.Error(w, "could not clear votes", http.StatusInternalServerError)
+				return
+			}
+		}
 
 		// 7) Delete existing options
 		if _, err := tx.PollOption.
@@ -710,7 +1091,17 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 			return
 		}
 
-		// 8) Create new options
+		// 8) Store the bounds and create new options
+		if p, err = tx.Poll.
+			UpdateOne(p).
+			SetMinChoices(minChoices).
+			SetMaxChoices(maxChoices).
+			Save(ctx); err != nil {
+			rollback()
+			log.Printf("failed updating poll: %v", err)
+			http.Error(w, "could not update options", http.StatusInternalServerError)
+			return
+		}
 		createdOpts := make([]*ent.PollOption, 0, len(req.Options))
 		for _, text := range req.Options {
 			o, err := tx.PollOption.
@@ -737,23 +1128,32 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 
 		// 10) Build response
 		type optionResp struct {
-			ID   int    `json:"id"`
-			Text string `json:"text"`
+			ID        int       `json:"id"`
+			Text      string    `json:"text"`
+			CreatedAt time.Time `json:"created_at"`
 		}
 		respOpts := make([]optionResp, len(createdOpts))
 		for i, o := range createdOpts {
-			respOpts[i] = optionResp{ID: o.ID, Text: o.Text}
+			respOpts[i] = optionResp{ID: o.ID, Text: o.Text, CreatedAt: o.CreatedAt}
 		}
 		resp := struct {
-			ID        int          `json:"id"`
-			Title     string       `json:"title"`
-			CreatorID int          `json:"creator_id"`
-			Options   []optionResp `json:"options"`
+			ID         int          `json:"id"`
+			Title      string       `json:"title"`
+			CreatorID  int          `json:"creator_id"`
+			MinChoices int          `json:"min_choices"`
+			MaxChoices int          `json:"max_choices"`
+			CreatedAt  time.Time    `json:"created_at"`
+			UpdatedAt  time.Time    `json:"updated_at"`
+			Options    []optionResp `json:"options"`
 		}{
-			ID:        p.ID,
-			Title:     p.Title,
-			CreatorID: p.CreatorID,
-			Options:   respOpts,
+			ID:         p.ID,
+			Title:      p.Title,
+			CreatorID:  p.CreatorID,
+			MinChoices: p.MinChoices,
+			MaxChoices: p.MaxChoices,
+			CreatedAt:  p.CreatedAt,
+			UpdatedAt:  p.UpdatedAt,
+			Options:    respOpts,
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -764,22 +1164,14 @@ func UpdatePoll(client *ent.Client) httprouter.Handle {
 	}
 }
 
-// DeletePoll allows the creator to delete their poll (and its options & votes).
+// DeletePoll allows the creator, or a role allowed to delete any poll, to
+// delete it (and its options & votes).
 func DeletePoll(client *ent.Client) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		ctx := r.Context()
 
-		// 1) Authenticate via cookie
-		c, err := r.Cookie("user_id")
-		if err != nil {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-		userID, err := strconv.Atoi(c.Value)
-		if err != nil {
-			http.Error(w, "invalid user_id cookie", http.StatusUnauthorized)
-			return
-		}
+		// 1) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		u := auth.UserFromContext(ctx)
 
 		// 2) Parse poll ID from path
 		pollID, err := strconv.Atoi(ps.ByName("id"))
@@ -802,8 +1194,7 @@ func DeletePoll(client *ent.Client) httprouter.Handle {
 			}
 			return
 		}
-		if p.CreatorID != userID {
-			http.Error(w, "forbidden", http.StatusForbidden)
+		if !checkManaged(ctx, w, client, u, p, authz.PollDeleteAny) {
 			return
 		}
 
@@ -820,7 +1211,7 @@ func DeletePoll(client *ent.Client) httprouter.Handle {
 			}
 		}
 
-		// 5) Delete all votes for this poll
+		// 5) Delete all votes for this poll and their history
 		if _, err := tx.Vote.
 			Delete().
 			Where(vote.PollIDEQ(pollID)).
@@ -830,6 +1221,15 @@ func DeletePoll(client *ent.Client) httprouter.Handle {
 			http.Error(w, "could not delete poll", http.StatusInternalServerError)
 			return
 		}
+		if _, err := tx.VoteHistory.
+			Delete().
+			Where(votehistory.PollIDEQ(pollID)).
+			Exec(ctx); err != nil {
+			rollback()
+			log.Printf("failed deleting vote history: %v", err)
+			http.Error(w, "could not delete poll", http.StatusInternalServerError)
+			return
+		}
 
 		// 6) Delete all options for this poll
 		if _, err := tx.PollOption.
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/lifecycle"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// PublishPoll makes a draft poll scheduled or open. The body may set
+// opens_at and closes_at; both are optional.
+func PublishPoll(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Decode the optional window
+		var req struct {
+			OpensAt  *time.Time `json:"opens_at"`
+			ClosesAt *time.Time `json:"closes_at"`
+		}
+		if !decodeOptional(w, r, &req) {
+			return
+		}
+
+		// 3) Publish it
+		p, err := lifecycle.Publish(r.Context(), client, p, req.OpensAt, req.ClosesAt, time.Now())
+		writeTransition(w, p, err, "only draft polls can be published")
+	}
+}
+
+// ClosePoll stops a poll from taking further votes. Its creator may close
+// it, as may roles allowed to close any poll.
+func ClosePoll(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may close it
+		p := managedPoll(w, r, ps, client, authz.PollCloseAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Close it
+		p, err := lifecycle.Close(r.Context(), client, p, time.Now())
+		writeTransition(w, p, err, "poll is not open")
+	}
+}
+
+// ReopenPoll lets a closed poll take votes again. The body may set a new
+// closes_at; without one the poll stays open until closed by hand.
+func ReopenPoll(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may close it
+		p := managedPoll(w, r, ps, client, authz.PollCloseAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Decode the optional deadline
+		var req struct {
+			ClosesAt *time.Time `json:"closes_at"`
+		}
+		if !decodeOptional(w, r, &req) {
+			return
+		}
+
+		// 3) Reopen it
+		p, err := lifecycle.Reopen(r.Context(), client, p, req.ClosesAt, time.Now())
+		writeTransition(w, p, err, "only closed polls can be reopened")
+	}
+}
+
+// ArchivePoll retires a closed poll for good.
+func ArchivePoll(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Archive it
+		p, err := lifecycle.Archive(r.Context(), client, p, time.Now())
+		writeTransition(w, p, err, "only closed polls can be archived")
+	}
+}
+
+// managedPoll loads the poll named in the path and checks that the caller
+// owns it or holds perm. On failure it has already written the response
+// and returns nil.
+func managedPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client, perm authz.Permission) *ent.Poll {
+	pollID, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "invalid poll id", http.StatusBadRequest)
+		return nil
+	}
+	p, err := client.Poll.Get(r.Context(), pollID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			http.Error(w, "poll not found", http.StatusNotFound)
+		} else {
+			log.Printf("query poll error: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return nil
+	}
+	if !checkManaged(r.Context(), w, client, auth.UserFromContext(r.Context()), p, perm) {
+		return nil
+	}
+	return p
+}
+
+// decodeOptional decodes a JSON body into v, allowing it to be empty. On
+// failure it has already written the response and returns false.
+func decodeOptional(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeTransition answers a lifecycle change with the poll's new state,
+// or with the reason it was refused.
+func writeTransition(w http.ResponseWriter, p *ent.Poll, err error, conflict string) {
+	switch {
+	case errors.Is(err, lifecycle.ErrTransition):
+		http.Error(w, conflict, http.StatusConflict)
+		return
+	case errors.Is(err, lifecycle.ErrWindow):
+		http.Error(w, "closes_at must be after opens_at and in the future", http.StatusBadRequest)
+		return
+	case err != nil:
+		log.Printf("failed updating poll status: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":         p.ID,
+		"status":     lifecycle.Status(p, time.Now()),
+		"opens_at":   p.OpensAt,
+		"closes_at":  p.ClosesAt,
+		"closed_at":  p.ClosedAt,
+		"updated_at": p.UpdatedAt,
+	}); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
+
+// writeVoteError answers a vote on a poll that isn't open with a
+// machine-readable code alongside the message.
+func writeVoteError(w http.ResponseWriter, err *lifecycle.VoteError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"error":   err.Code,
+		"message": err.Message,
+	}); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
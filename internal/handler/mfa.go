@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/mfa"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// mfaRequest is the body of every 2FA route that needs a code.
+type mfaRequest struct {
+	Code string `json:"code"`
+}
+
+// writeMFAChallenge tells a client whose password was correct to come back
+// with a second factor for mfaToken.
+func writeMFAChallenge(w http.ResponseWriter, status int, mfaToken string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":        "mfa_required",
+		"mfa_required": true,
+		"mfa_token":    mfaToken,
+	}); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
+
+// checkReauth reports whether u may have another password or code checked,
+// whether signed in or completing a login. Otherwise it has already
+// answered 429.
+func checkReauth(w http.ResponseWriter, r *http.Request, limits *ratelimit.Limits, u *ent.User) bool {
+	if err := limits.Reauth.Check(r.Context(), strconv.Itoa(u.ID)); err != nil {
+		writeLimitError(w, err)
+		return false
+	}
+	return true
+}
+
+// countReauth records the outcome of a check allowed by checkReauth: a
+// failure counts against u, a success clears its count.
+func countReauth(ctx context.Context, limits *ratelimit.Limits, u *ent.User, ok bool) {
+	key := strconv.Itoa(u.ID)
+	if ok {
+		if err := limits.Reauth.Reset(ctx, key); err != nil {
+			log.Printf("failed resetting reauth failures of user %d: %v", u.ID, err)
+		}
+		return
+	}
+	if err := limits.Reauth.Hit(ctx, key); err != nil {
+		log.Printf("failed counting reauth failure of user %d: %v", u.ID, err)
+	}
+}
+
+// redeemChallenge checks code against the mfa challenge mfaToken and, when
+// it matches, uses the challenge up and returns its user. Wrong codes count
+// against the user as well as the challenge, so a known password can't buy
+// fresh challenges to keep guessing. On failure it has already written the
+// response and returns nil.
+func redeemChallenge(w http.ResponseWriter, r *http.Request, client *ent.Client, limits *ratelimit.Limits, mfaToken, code string) *ent.User {
+	ctx := r.Context()
+
+	// 1) Find the pending challenge
+	ch, err := session.LookupChallenge(ctx, client, mfaToken)
+	if err != nil {
+		if errors.Is(err, session.ErrInvalid) {
+			http.Error(w, "invalid or expired mfa token", http.StatusUnauthorized)
+			return nil
+		}
+		log.Printf("failed looking up mfa challenge: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil
+	}
+
+	// 2) Check the second factor; wrong codes count against the challenge
+	// and its user
+	u := ch.Edges.User
+	if !checkReauth(w, r, limits, u) {
+		return nil
+	}
+	if err := mfa.Verify(ctx, client, u, code); err != nil {
+		if errors.Is(err, mfa.ErrInvalidCode) || errors.Is(err, mfa.ErrNotEnrolled) {
+			if err := session.FailChallenge(ctx, client, ch); err != nil {
+				log.Printf("failed recording mfa attempt: %v", err)
+			}
+			countReauth(ctx, limits, u, false)
+			http.Error(w, "invalid two-factor code", http.StatusUnauthorized)
+			return nil
+		}
+		log.Printf("failed verifying two-factor code: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil
+	}
+
+	// 3) A challenge is good for one login only
+	if err := session.Revoke(ctx, client, ch.ID); err != nil {
+		if ent.IsNotFound(err) {
+			http.Error(w, "invalid or expired mfa token", http.StatusUnauthorized)
+			return nil
+		}
+		log.Printf("failed revoking mfa challenge: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil
+	}
+	countReauth(ctx, limits, u, true)
+	return u
+}
+
+// LoginMFA completes a password login on an account with 2FA: it trades the
+// mfa_token from Login plus a TOTP or recovery code for a session cookie.
+func LoginMFA(client *ent.Client, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Decode JSON body
+		var req struct {
+			MFAToken string `json:"mfa_token"`
+			Code     string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Check the second factor
+		u := redeemChallenge(w, r, client, limits, req.MFAToken, req.Code)
+		if u == nil {
+			return
+		}
+
+		// 3) Start the session the password login was waiting for
+		token, sess, err := session.Create(ctx, client, u.ID, r)
+		if err != nil {
+			log.Printf("failed creating session: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		session.SetCookie(w, token, sess.ExpiresAt)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"login successful"}`))
+	}
+}
+
+// MFAStatus reports whether the caller uses 2FA and must do so.
+func MFAStatus(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		required, err := mfa.Required(ctx, client, u)
+		if err != nil {
+			log.Printf("failed checking 2fa policy: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		remaining := 0
+		if u.TotpEnabled {
+			if remaining, err = mfa.RemainingRecoveryCodes(ctx, client, u.ID); err != nil {
+				log.Printf("failed counting recovery codes: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"enabled":                  u.TotpEnabled,
+			"required":                 required,
+			"recovery_codes_remaining": remaining,
+		})
+	}
+}
+
+// EnrollMFA starts TOTP enrollment and returns the secret and provisioning
+// URI; the same URI is available as a QR code at /me/2fa/qr.png. 2FA stays
+// off until ConfirmMFA sees a code from the authenticator app.
+func EnrollMFA(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		secret, uri, err := mfa.Enroll(ctx, client, u)
+		if err != nil {
+			if errors.Is(err, mfa.ErrAlreadyEnabled) {
+				http.Error(w, "two-factor authentication already enabled", http.StatusConflict)
+				return
+			}
+			log.Printf("failed enrolling user %d in 2fa: %v", u.ID, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"secret":           secret,
+			"provisioning_uri": uri,
+			"qr_code_url":      "/me/2fa/qr.png",
+		})
+	}
+}
+
+// MFAQRCode serves the pending enrollment's provisioning URI as a QR PNG.
+func MFAQRCode() httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		u := auth.UserFromContext(r.Context())
+
+		png, err := mfa.QRCode(u)
+		if err != nil {
+			switch {
+			case errors.Is(err, mfa.ErrAlreadyEnabled):
+				http.Error(w, "two-factor authentication already enabled", http.StatusConflict)
+			case errors.Is(err, mfa.ErrNotEnrolled):
+				http.Error(w, "no pending enrollment; POST /me/2fa first", http.StatusNotFound)
+			default:
+				log.Printf("failed rendering qr code: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write(png)
+	}
+}
+
+// ConfirmMFA finishes enrollment with a code from the authenticator app and
+// returns the recovery codes. They are shown only this once.
+func ConfirmMFA(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		var req mfaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		codes, err := mfa.Confirm(ctx, client, u, req.Code)
+		if err != nil {
+			switch {
+			case errors.Is(err, mfa.ErrAlreadyEnabled):
+				http.Error(w, "two-factor authentication already enabled", http.StatusConflict)
+			case errors.Is(err, mfa.ErrNotEnrolled):
+				http.Error(w, "no pending enrollment; POST /me/2fa first", http.StatusBadRequest)
+			case errors.Is(err, mfa.ErrInvalidCode):
+				http.Error(w, "invalid two-factor code", http.StatusBadRequest)
+			default:
+				log.Printf("failed confirming 2fa for user %d: %v", u.ID, err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"enabled":        true,
+			"recovery_codes": codes,
+		})
+	}
+}
+
+// RegenerateRecoveryCodes replaces the caller's recovery codes, e.g. when
+// they are running out, after checking a current code. Wrong codes back off
+// like failed logins.
+func RegenerateRecoveryCodes(client *ent.Client, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		var req mfaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		if !checkReauth(w, r, limits, u) {
+			return
+		}
+		codes, err := mfa.RegenerateRecoveryCodes(ctx, client, u, req.Code)
+		if errors.Is(err, mfa.ErrInvalidCode) || err == nil {
+			countReauth(ctx, limits, u, err == nil)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, mfa.ErrNotEnrolled):
+				http.Error(w, "two-factor authentication is not enabled", http.StatusConflict)
+			case errors.Is(err, mfa.ErrInvalidCode):
+				http.Error(w, "invalid two-factor code", http.StatusBadRequest)
+			default:
+				log.Printf("failed regenerating recovery codes for user %d: %v", u.ID, err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"recovery_codes": codes,
+		})
+	}
+}
+
+// DisableMFA turns 2FA off after checking a current code, unless
+// -require-2fa applies to the caller. Wrong codes back off like failed
+// logins.
+func DisableMFA(client *ent.Client, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		var req mfaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		if !checkReauth(w, r, limits, u) {
+			return
+		}
+		err := mfa.Disable(ctx, client, u, req.Code)
+		if errors.Is(err, mfa.ErrInvalidCode) || err == nil {
+			countReauth(ctx, limits, u, err == nil)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, mfa.ErrRequired):
+				http.Error(w, "two-factor authentication is required for this account", http.StatusForbidden)
+			case errors.Is(err, mfa.ErrNotEnrolled):
+				http.Error(w, "two-factor authentication is not enabled", http.StatusConflict)
+			case errors.Is(err, mfa.ErrInvalidCode):
+				http.Error(w, "invalid two-factor code", http.StatusBadRequest)
+			default:
+				log.Printf("failed disabling 2fa for user %d: %v", u.ID, err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
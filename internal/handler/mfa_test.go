@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pollAppNew/ent"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// loginMFA starts a fresh 2FA challenge for u, as a correct password
+// would, and redeems it with code.
+func loginMFA(t *testing.T, h httprouter.Handle, client *ent.Client, u *ent.User, code string) *httptest.ResponseRecorder {
+	t.Helper()
+	challenge, _, err := session.CreateChallenge(context.Background(), client, u.ID, httptest.NewRequest("POST", "/login", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(map[string]string{"mfa_token": challenge, "code": code})
+	return call(h, "POST", "/login/2fa", string(body))
+}
+
+// Wrong codes count against the user, so fresh challenges from a known
+// password don't buy more guesses.
+func TestLoginMFAThrottlesPerUser(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := client.User.Create().SetUsername("alice").SetTotpEnabled(true).SetTotpSecret("JBSWY3DPEHPK3PXP").SaveX(ctx)
+	client.RecoveryCode.Create().SetUserID(u.ID).SetCodeHash(session.HashToken("aaaaabbbbb")).ExecX(ctx)
+	client.RecoveryCode.Create().SetUserID(u.ID).SetCodeHash(session.HashToken("cccccddddd")).ExecX(ctx)
+	limits := ratelimit.FromFlags(ratelimit.NewMemoryStore())
+	h := LoginMFA(client, limits)
+
+	// a success clears the failures before it
+	for range 2 {
+		if w := loginMFA(t, h, client, u, "000000"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("wrong code: %d %s", w.Code, w.Body)
+		}
+	}
+	if w := loginMFA(t, h, client, u, "aaaaa-bbbbb"); w.Code != http.StatusOK {
+		t.Fatalf("recovery code: %d %s", w.Code, w.Body)
+	}
+
+	for range 3 {
+		if w := loginMFA(t, h, client, u, "000000"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("wrong code: %d %s", w.Code, w.Body)
+		}
+	}
+	// even the right code waits out the backoff, on a fresh challenge too
+	if w := loginMFA(t, h, client, u, "ccccc-ddddd"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("backing off: %d %s", w.Code, w.Body)
+	}
+	if n := client.RecoveryCode.Query().CountX(ctx); n != 2 {
+		t.Fatalf("%d recovery codes", n)
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/oauth2"
+)
+
+// oidcFlowCookie carries the state, nonce and PKCE verifier of an in-flight
+// login between the redirect to the provider and the callback.
+const oidcFlowCookie = "oidc_flow"
+
+type oidcFlow struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Next     string `json:"next"`
+}
+
+// OIDCLogin starts an authorization-code + PKCE login by redirecting the
+// browser to the identity provider. ?next= is where to land afterwards.
+func OIDCLogin(provider *sso.OIDC) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		// 1) Fresh per-login secrets
+		flow := oidcFlow{
+			State:    randomString(),
+			Nonce:    randomString(),
+			Verifier: oauth2.GenerateVerifier(),
+			Next:     safeNext(r.URL.Query().Get("next")),
+		}
+		raw, err := json.Marshal(flow)
+		if err != nil {
+			log.Printf("failed encoding oidc flow: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 2) Remember them in a short-lived cookie; Lax so it survives the
+		//    top-level redirect back from the provider
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcFlowCookie,
+			Value:    base64.RawURLEncoding.EncodeToString(raw),
+			Path:     "/auth/oidc",
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   int((10 * time.Minute).Seconds()),
+		})
+
+		// 3) Off to the provider
+		http.Redirect(w, r, provider.AuthCodeURL(flow.State, flow.Nonce, flow.Verifier), http.StatusFound)
+	}
+}
+
+// OIDCCallback completes the login: it checks state, redeems the code with
+// the PKCE verifier, provisions or links the user and starts a session. For
+// accounts with 2FA it returns an mfa_token to finish at /login/2fa instead,
+// as Login does.
+func OIDCCallback(client *ent.Client, provider *sso.OIDC) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		q := r.URL.Query()
+
+		// 1) Recover the flow and clear its cookie; it is single-use
+		c, err := r.Cookie(oidcFlowCookie)
+		if err != nil {
+			http.Error(w, "login flow expired, please retry", http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: oidcFlowCookie, Path: "/auth/oidc", MaxAge: -1})
+		var flow oidcFlow
+		raw, err := base64.RawURLEncoding.DecodeString(c.Value)
+		if err != nil || json.Unmarshal(raw, &flow) != nil {
+			http.Error(w, "invalid login flow", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Provider-reported errors and CSRF check on state
+		if e := q.Get("error"); e != "" {
+			http.Error(w, "identity provider error: "+e, http.StatusUnauthorized)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(flow.State)) != 1 {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Redeem the code and verify the ID token
+		claims, err := provider.Exchange(ctx, q.Get("code"), flow.Verifier, flow.Nonce)
+		if err != nil {
+			log.Printf("oidc exchange failed: %v", err)
+			http.Error(w, "sso login failed", http.StatusUnauthorized)
+			return
+		}
+
+		// 4) Find, link or create the local user
+		u, err := sso.Provision(ctx, client, provider.Issuer(), claims, provider.LinkExisting())
+		if err != nil {
+			log.Printf("failed provisioning oidc user %q: %v", claims.Subject, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if u.BannedAt != nil {
+			http.Error(w, "account banned", http.StatusForbidden)
+			return
+		}
+		if !u.Active {
+			http.Error(w, "account deactivated", http.StatusForbidden)
+			return
+		}
+
+		// 5) The IdP stands in for the password only; 2FA still applies
+		if u.TotpEnabled {
+			challenge, _, err := session.CreateChallenge(ctx, client, u.ID, r)
+			if err != nil {
+				log.Printf("failed creating mfa challenge: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			writeMFAChallenge(w, http.StatusOK, challenge)
+			return
+		}
+
+		// 6) Start a session exactly like a password login
+		token, sess, err := session.Create(ctx, client, u.ID, r)
+		if err != nil {
+			log.Printf("failed creating session: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		session.SetCookie(w, token, sess.ExpiresAt)
+
+		http.Redirect(w, r, flow.Next, http.StatusFound)
+	}
+}
+
+// safeNext returns next if it is a same-site relative path, and "/" for
+// anything else, including "//host" and absolute URLs.
+func safeNext(next string) string {
+	if len(next) < 1 || next[0] != '/' || (len(next) > 1 && (next[1] == '/' || next[1] == '\\')) {
+		return "/"
+	}
+	return next
+}
+
+// randomString returns 128 bits of URL-safe randomness.
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// The callback must reject a flow before it reaches the provider or the
+// database, so neither is needed here.
+func TestOIDCCallbackRejectsFlow(t *testing.T) {
+	flow, _ := json.Marshal(oidcFlow{State: "good-state", Nonce: "n", Verifier: "v", Next: "/"})
+	cookie := &http.Cookie{Name: oidcFlowCookie, Value: base64.RawURLEncoding.EncodeToString(flow)}
+
+	tests := []struct {
+		name   string
+		query  string
+		cookie *http.Cookie
+		want   int
+	}{
+		{"no flow cookie", "?state=good-state&code=c", nil, http.StatusBadRequest},
+		{"garbled flow cookie", "?state=good-state&code=c", &http.Cookie{Name: oidcFlowCookie, Value: "%%%"}, http.StatusBadRequest},
+		{"state mismatch", "?state=bad-state&code=c", cookie, http.StatusBadRequest},
+		{"missing state", "?code=c", cookie, http.StatusBadRequest},
+		{"provider error", "?error=access_denied&state=good-state", cookie, http.StatusUnauthorized},
+	}
+	h := OIDCCallback(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/auth/oidc/callback"+tt.query, nil)
+			if tt.cookie != nil {
+				r.AddCookie(tt.cookie)
+			}
+			w := httptest.NewRecorder()
+			h(w, r, nil)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
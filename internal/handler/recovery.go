@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/usertoken"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/mailtoken"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	verifyEmailTTL   = 48 * time.Hour
+	resetPasswordTTL = time.Hour
+)
+
+// normalizeEmail trims and lower-cases an address so lookups are case-insensitive.
+func normalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// sendVerification mails u a link confirming they own their current address.
+func sendVerification(ctx context.Context, client *ent.Client, mailer mail.Mailer, u *ent.User) error {
+	raw, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeVerifyEmail, *u.Email, verifyEmailTTL)
+	if err != nil {
+		return err
+	}
+	return mailer.Send(ctx, mail.Message{
+		To:      *u.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nconfirm this address for your poll account by opening:\n\n%s\n\nThe link expires in %s. If you didn't sign up, ignore this email.\n",
+			u.Username, mail.Link("/verify-email", raw), verifyEmailTTL),
+	})
+}
+
+// VerifyEmail redeems a verification token and marks the address verified.
+func VerifyEmail(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Decode JSON body
+		var req struct {
+			Token string `json:"token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Redeem the token
+		t, err := mailtoken.Consume(ctx, client, req.Token, usertoken.PurposeVerifyEmail)
+		if err != nil {
+			if errors.Is(err, mailtoken.ErrInvalid) {
+				http.Error(w, "invalid or expired token", http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed redeeming verification token: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 3) Only the address the link was sent to can be verified
+		n, err := client.User.
+			Update().
+			Where(user.IDEQ(t.UserID), user.EmailEQ(t.Email)).
+			SetEmailVerified(true).
+			Save(ctx)
+		if err != nil {
+			log.Printf("failed marking email verified: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if n == 0 {
+			http.Error(w, "email address has changed since this link was sent", http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"email verified"}`))
+	}
+}
+
+// ResendVerification mails the caller a fresh verification link.
+func ResendVerification(client *ent.Client, mailer mail.Mailer) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		if u.Email == nil {
+			http.Error(w, "no email address on this account", http.StatusBadRequest)
+			return
+		}
+		if u.EmailVerified {
+			http.Error(w, "email already verified", http.StatusConflict)
+			return
+		}
+		if err := sendVerification(ctx, client, mailer, u); err != nil {
+			log.Printf("failed sending verification email to user %d: %v", u.ID, err)
+			http.Error(w, "could not send verification email", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// ForgotPassword mails a single-use reset link to a verified address. It
+// always answers 202 so it can't be used to discover registered addresses.
+func ForgotPassword(client *ent.Client, mailer mail.Mailer) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		if sso.SSOOnly() {
+			http.Error(w, "passwords are disabled; sign in with SSO", http.StatusForbidden)
+			return
+		}
+
+		// 1) Decode JSON body
+		var req struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Look up a user with that verified address
+		u, err := client.User.
+			Query().
+			Where(user.EmailEQ(normalizeEmail(req.Email)), user.EmailVerified(true)).
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			// fall through to the same response
+		case err != nil:
+			log.Printf("failed looking up user by email: %v", err)
+		default:
+			// 3) Mail the reset link
+			raw, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeResetPassword, *u.Email, resetPasswordTTL)
+			if err == nil {
+				err = mailer.Send(ctx, mail.Message{
+					To:      *u.Email,
+					Subject: "Reset your password",
+					Body: fmt.Sprintf("Hi %s,\n\nsomeone asked to reset the password of your poll account. To choose a new one, open:\n\n%s\n\nThe link expires in %s and works once. If it wasn't you, ignore this email.\n",
+						u.Username, mail.Link("/reset-password", raw), resetPasswordTTL),
+				})
+			}
+			if err != nil {
+				log.Printf("failed sending password reset to user %d: %v", u.ID, err)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"message":"if the address is registered and verified, a reset link has been sent"}`))
+	}
+}
+
+// ResetPassword redeems a reset token, sets the new password and signs the
+// user out everywhere, revoking their personal access tokens too.
+func ResetPassword(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		if sso.SSOOnly() {
+			http.Error(w, "passwords are disabled; sign in with SSO", http.StatusForbidden)
+			return
+		}
+
+		// 1) Decode JSON body
+		var req struct {
+			Token    string `json:"token"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		if req.Password == "" {
+			http.Error(w, "password required", http.StatusBadRequest)
+			return
+		}
+		hash, err := password.Hash(req.Password)
+		if err != nil {
+			if errors.Is(err, password.ErrTooLong) {
+				http.Error(w, "password must be at most 72 bytes", http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed hashing password: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 2) Begin transaction so the token is only burnt if the reset succeeds
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+
+		// 3) Redeem the token
+		t, err := mailtoken.Consume(ctx, tx.Client(), req.Token, usertoken.PurposeResetPassword)
+		if err != nil {
+			rollback()
+			if errors.Is(err, mailtoken.ErrInvalid) {
+				http.Error(w, "invalid or expired token", http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed redeeming reset token: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Store the new password and revoke every session and token
+		if err := tx.User.
+			UpdateOneID(t.UserID).
+			SetPasswordHash(hash).
+			Exec(ctx); err != nil {
+			rollback()
+			log.Printf("failed updating password: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := session.RevokeAll(ctx, tx.Client(), t.UserID); err != nil {
+			rollback()
+			log.Printf("failed revoking sessions: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := apitoken.RevokeAll(ctx, tx.Client(), t.UserID); err != nil {
+			rollback()
+			log.Printf("failed revoking access tokens: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 5) Commit transaction
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"password has been reset"}`))
+	}
+}
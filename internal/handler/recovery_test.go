@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/enttest"
+	"pollAppNew/ent/usertoken"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/mailtoken"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/session"
+
+	"github.com/julienschmidt/httprouter"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// call runs h on a request with a JSON body and returns the recorder.
+func call(h httprouter.Handle, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(method, path, strings.NewReader(body)), nil)
+	return w
+}
+
+var linkToken = regexp.MustCompile(`token=([A-Za-z0-9_%-]+)`)
+
+// lastLink returns the token in the newest message of the outbox, and how
+// many messages it holds.
+func lastLink(t *testing.T, o *mail.Outbox) (string, int) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(o.Dir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		return "", 0
+	}
+	sort.Strings(files)
+	raw, err := os.ReadFile(files[len(files)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := linkToken.FindStringSubmatch(string(raw))
+	if m == nil {
+		t.Fatalf("no link in %s", raw)
+	}
+	tok, err := url.QueryUnescape(m[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tok, len(files)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	outbox, err := mail.NewOutbox(t.TempDir(), "polls@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := client.User.Create().SetUsername("alice").SetEmail("alice@example.com").SaveX(ctx)
+	h := VerifyEmail(client)
+
+	if err := sendVerification(ctx, client, outbox, u); err != nil {
+		t.Fatal(err)
+	}
+	tok, _ := lastLink(t, outbox)
+	if w := call(h, "POST", "/verify-email", `{"token":"`+tok+`"}`); w.Code != http.StatusOK {
+		t.Fatalf("verify: %d %s", w.Code, w.Body)
+	}
+	if !client.User.GetX(ctx, u.ID).EmailVerified {
+		t.Fatal("email not marked verified")
+	}
+	if w := call(h, "POST", "/verify-email", `{"token":"`+tok+`"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("reused token: %d", w.Code)
+	}
+
+	expired, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeVerifyEmail, "alice@example.com", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call(h, "POST", "/verify-email", `{"token":"`+expired+`"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expired token: %d", w.Code)
+	}
+
+	// a link for an address the user has since replaced verifies nothing
+	stale, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeVerifyEmail, "old@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call(h, "POST", "/verify-email", `{"token":"`+stale+`"}`); w.Code != http.StatusConflict {
+		t.Fatalf("stale address: %d", w.Code)
+	}
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	outbox, err := mail.NewOutbox(t.TempDir(), "polls@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := client.User.Create().
+		SetUsername("alice").
+		SetEmail("alice@example.com").
+		SetEmailVerified(true).
+		SaveX(ctx)
+	client.User.Create().SetUsername("bob").SetEmail("bob@example.com").SaveX(ctx)
+	forgot, reset := ForgotPassword(client, outbox), ResetPassword(client)
+
+	// 1) Unknown and unverified addresses get the same answer and no mail
+	for _, email := range []string{"nobody@example.com", "bob@example.com"} {
+		if w := call(forgot, "POST", "/password/forgot", `{"email":"`+email+`"}`); w.Code != http.StatusAccepted {
+			t.Fatalf("forgot %s: %d", email, w.Code)
+		}
+	}
+	if _, n := lastLink(t, outbox); n != 0 {
+		t.Fatalf("%d messages sent to unknown or unverified addresses", n)
+	}
+
+	// 2) A verified address gets a link, whatever its case
+	if w := call(forgot, "POST", "/password/forgot", `{"email":" Alice@Example.com "}`); w.Code != http.StatusAccepted {
+		t.Fatalf("forgot: %d", w.Code)
+	}
+	tok, n := lastLink(t, outbox)
+	if n != 1 {
+		t.Fatalf("%d messages, want 1", n)
+	}
+
+	// 3) Resetting signs out every session and revokes every access token
+	if _, _, err := session.Create(ctx, client, u.ID, httptest.NewRequest("GET", "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := apitoken.Create(ctx, client, u.ID, "ci", []string{"polls:read"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if w := call(reset, "POST", "/password/reset", `{"token":"`+tok+`","password":"new secret"}`); w.Code != http.StatusOK {
+		t.Fatalf("reset: %d %s", w.Code, w.Body)
+	}
+	if ok, _ := password.Verify(client.User.GetX(ctx, u.ID).PasswordHash, "new secret"); !ok {
+		t.Fatal("new password not stored")
+	}
+	if n := client.Session.Query().CountX(ctx); n != 0 {
+		t.Fatalf("%d sessions left", n)
+	}
+	if n := client.AccessToken.Query().CountX(ctx); n != 0 {
+		t.Fatalf("%d access tokens left", n)
+	}
+
+	// 4) A link works once, and not after it expires
+	if w := call(reset, "POST", "/password/reset", `{"token":"`+tok+`","password":"again"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("reused token: %d", w.Code)
+	}
+	expired, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeResetPassword, "alice@example.com", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call(reset, "POST", "/password/reset", `{"token":"`+expired+`","password":"again"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expired token: %d", w.Code)
+	}
+	// a verification token is no reset token
+	verify, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeVerifyEmail, "alice@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call(reset, "POST", "/password/reset", `{"token":"`+verify+`","password":"again"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("wrong purpose: %d", w.Code)
+	}
+	if ok, _ := password.Verify(client.User.GetX(ctx, u.ID).PasswordHash, "new secret"); !ok {
+		t.Fatal("password changed by a rejected reset")
+	}
+
+	// 5) Under -sso-only there are no passwords to reset
+	flag.Set("sso-only", "true")
+	defer flag.Set("sso-only", "false")
+	valid, err := mailtoken.Issue(ctx, client, u.ID, usertoken.PurposeResetPassword, "alice@example.com", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := call(reset, "POST", "/password/reset", `{"token":"`+valid+`","password":"again"}`); w.Code != http.StatusForbidden {
+		t.Fatalf("reset under -sso-only: %d", w.Code)
+	}
+	if w := call(forgot, "POST", "/password/forgot", `{"email":"alice@example.com"}`); w.Code != http.StatusForbidden {
+		t.Fatalf("forgot under -sso-only: %d", w.Code)
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"pollAppNew/ent"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/polloption"
+	"pollAppNew/ent/vote"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/lifecycle"
+	"pollAppNew/internal/tally"
+	"time"
+)
+
+// optionResult is one option's line in a poll's results. On ranked polls
+// Votes counts first preferences; it is nil on rated polls, where every
+// ballot scores every option and Score holds the totals, and while the
+// results are hidden.
+type optionResult struct {
+	OptionID int    `json:"option_id"`
+	Text     string `json:"text"`
+	Votes    *int   `json:"votes,omitempty"`
+}
+
+// pollResults is what Vote and GetResults report: per-option counts and,
+// since a ballot may select several options, the number of distinct voters.
+// Ranked polls add the tally of their method, and all of them a Schulze
+// tally so an IRV outcome can be checked against the Condorcet one.
+type pollResults struct {
+	PollID        int                `json:"poll_id"`
+	Method        poll.Method        `json:"method"`
+	ResultsPolicy poll.ResultsPolicy `json:"results_policy"`
+	// Hidden is set when the results policy withholds the counts from the
+	// caller; only the options are listed then.
+	Hidden  bool                 `json:"results_hidden,omitempty"`
+	Voters  *int                 `json:"voters,omitempty"`
+	Results []optionResult       `json:"results"`
+	IRV     *tally.IRVResult     `json:"irv,omitempty"`
+	Schulze *tally.SchulzeResult `json:"schulze,omitempty"`
+	Score   *tally.ScoreResult   `json:"score,omitempty"`
+}
+
+// redact withholds the counts and tallies of res, keeping its options.
+func (res *pollResults) redact() {
+	res.Hidden = true
+	res.Voters = nil
+	for i := range res.Results {
+		res.Results[i].Votes = nil
+	}
+	res.IRV, res.Schulze, res.Score = nil, nil, nil
+}
+
+// resultsVisible reports whether u may see the counts of p under its
+// results policy. The poll's owner, and roles allowed to edit any poll,
+// always may.
+func resultsVisible(ctx context.Context, client *ent.Client, u *ent.User, p *ent.Poll) (bool, error) {
+	if authz.CanManagePoll(u, p, authz.PollEditAny) {
+		return true, nil
+	}
+	switch p.ResultsPolicy {
+	case poll.ResultsPolicyAlways:
+		return true, nil
+	case poll.ResultsPolicyAfterVote:
+		if u == nil {
+			return false, nil
+		}
+		return client.Vote.
+			Query().
+			Where(vote.UserIDEQ(u.ID), vote.PollIDEQ(p.ID)).
+			Exist(ctx)
+	case poll.ResultsPolicyAfterClose:
+		status := lifecycle.Status(p, time.Now())
+		return status == poll.StatusClosed || status == poll.StatusArchived, nil
+	}
+	return false, nil
+}
+
+// ranked reports whether ballots under method rank options rather than
+// select them.
+func ranked(method poll.Method) bool {
+	return method == poll.MethodIrv || method == poll.MethodSchulze
+}
+
+// rated reports whether ballots under method score every option.
+func rated(method poll.Method) bool {
+	return method == poll.MethodScore || method == poll.MethodStar
+}
+
+// optionVotes counts the votes of p's option among votes, only first
+// preferences on ranked polls. It returns nil on rated polls, where the count
+// would just be the number of voters.
+func optionVotes(p *ent.Poll, votes []*ent.Vote) *int {
+	if rated(p.Method) {
+		return nil
+	}
+	n := 0
+	for _, v := range votes {
+		if !ranked(p.Method) || v.Position == 0 {
+			n++
+		}
+	}
+	return &n
+}
+
+func loadResults(ctx context.Context, client *ent.Client, p *ent.Poll) (*pollResults, error) {
+	// 1) Load options and every ballot, in ballot order
+	opts, err := client.PollOption.
+		Query().
+		Where(polloption.PollIDEQ(p.ID)).
+		Order(ent.Asc(polloption.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := client.Vote.
+		Query().
+		Where(vote.PollIDEQ(p.ID)).
+		Order(ent.Asc(vote.FieldUserID), ent.Asc(vote.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Group votes into ballots and count each option
+	var (
+		ballots []tally.Ballot
+		ratings []tally.Ratings
+	)
+	byOption := make(map[int][]*ent.Vote, len(opts))
+	for i, v := range votes {
+		if i == 0 || votes[i-1].UserID != v.UserID {
+			ballots = append(ballots, nil)
+			ratings = append(ratings, tally.Ratings{})
+		}
+		ballots[len(ballots)-1] = append(ballots[len(ballots)-1], v.OptionID)
+		if v.Score != nil {
+			ratings[len(ratings)-1][v.OptionID] = *v.Score
+		}
+		byOption[v.OptionID] = append(byOption[v.OptionID], v)
+	}
+	voters := len(ballots)
+	res := &pollResults{
+		PollID:        p.ID,
+		Method:        p.Method,
+		ResultsPolicy: p.ResultsPolicy,
+		Voters:        &voters,
+		Results:       make([]optionResult, len(opts)),
+	}
+	ids := make([]int, len(opts))
+	for i, o := range opts {
+		ids[i] = o.ID
+		res.Results[i] = optionResult{
+			OptionID: o.ID,
+			Text:     o.Text,
+			Votes:    optionVotes(p, byOption[o.ID]),
+		}
+	}
+
+	// 3) Run the method's tally
+	if p.Method == poll.MethodIrv {
+		irv := tally.IRV(ids, ballots)
+		res.IRV = &irv
+	}
+	if ranked(p.Method) {
+		schulze := tally.Schulze(ids, ballots)
+		res.Schulze = &schulze
+	}
+	if rated(p.Method) {
+		score := tally.Score(ids, ratings, p.ScoreMin, p.ScoreMax, p.Method == poll.MethodStar)
+		res.Score = &score
+	}
+	return res, nil
+}
+
+// visibleResults is loadResults with the counts redacted when the results
+// policy of p hides them from u.
+func visibleResults(ctx context.Context, client *ent.Client, u *ent.User, p *ent.Poll) (*pollResults, error) {
+	res, err := loadResults(ctx, client, p)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := resultsVisible(ctx, client, u, p)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		res.redact()
+	}
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"pollAppNew/ent/poll"
+)
+
+// Per-option counts mean selections, or first preferences on ranked polls;
+// rated polls leave them out, since every ballot scores every option.
+func TestLoadResultsOptionVotes(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	alice := client.User.Create().SetUsername("alice").SaveX(ctx)
+	bob := client.User.Create().SetUsername("bob").SaveX(ctx)
+
+	for _, tc := range []struct {
+		method poll.Method
+		want   []int // nil: no counts
+	}{
+		{poll.MethodPlurality, []int{2, 2}},
+		{poll.MethodIrv, []int{1, 1}},
+		{poll.MethodScore, nil},
+		{poll.MethodStar, nil},
+	} {
+		p := client.Poll.Create().SetTitle("t").SetCreatorID(alice.ID).SetMethod(tc.method).SaveX(ctx)
+		o1 := client.PollOption.Create().SetPollID(p.ID).SetText("a").SaveX(ctx)
+		o2 := client.PollOption.Create().SetPollID(p.ID).SetText("b").SaveX(ctx)
+		for i, u := range []int{alice.ID, bob.ID} {
+			first, second := o1.ID, o2.ID
+			if i == 1 {
+				first, second = second, first
+			}
+			client.Vote.Create().SetUserID(u).SetPollID(p.ID).SetOptionID(first).SetPosition(0).SetScore(5).ExecX(ctx)
+			client.Vote.Create().SetUserID(u).SetPollID(p.ID).SetOptionID(second).SetPosition(1).SetScore(1).ExecX(ctx)
+		}
+
+		res, err := loadResults(ctx, client, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, r := range res.Results {
+			switch {
+			case tc.want == nil && r.Votes != nil:
+				t.Errorf("%s: option %d reports %d votes", tc.method, i, *r.Votes)
+			case tc.want != nil && (r.Votes == nil || *r.Votes != tc.want[i]):
+				t.Errorf("%s: option %d votes = %v, want %d", tc.method, i, r.Votes, tc.want[i])
+			}
+		}
+		if rated(tc.method) && (res.Score == nil || res.Score.Scores[0].Total != 6) {
+			t.Errorf("%s: score tally %+v, want totals of 6", tc.method, res.Score)
+		}
+	}
+}
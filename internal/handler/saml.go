@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// samlFlowCookie carries the request ID and relay state of an SP-initiated
+// login between the redirect to the IdP and the POST back to the ACS.
+const samlFlowCookie = "saml_flow"
+
+type samlFlow struct {
+	RequestID  string `json:"request_id"`
+	RelayState string `json:"relay_state"`
+	Next       string `json:"next"`
+}
+
+// SAMLMetadata publishes the service-provider metadata to register with the IdP.
+func SAMLMetadata(provider *sso.SAML) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		raw, err := provider.Metadata()
+		if err != nil {
+			log.Printf("failed encoding saml metadata: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.Write(raw)
+	}
+}
+
+// SAMLLogin starts an SP-initiated login by redirecting the browser to the
+// IdP with a signed AuthnRequest. ?next= is where to land afterwards.
+func SAMLLogin(provider *sso.SAML) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		// 1) Build the request; the relay state ties the response to this browser
+		flow := samlFlow{
+			RelayState: randomString(),
+			Next:       safeNext(r.URL.Query().Get("next")),
+		}
+		dest, id, err := provider.AuthnRequestURL(flow.RelayState)
+		if err != nil {
+			log.Printf("failed creating saml request: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		flow.RequestID = id
+		raw, err := json.Marshal(flow)
+		if err != nil {
+			log.Printf("failed encoding saml flow: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 2) Remember it in a short-lived cookie; the response arrives as a
+		//    cross-site POST, which only SameSite=None cookies survive
+		sameSite := http.SameSiteLaxMode
+		if provider.SecureCookies() {
+			sameSite = http.SameSiteNoneMode
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     samlFlowCookie,
+			Value:    base64.RawURLEncoding.EncodeToString(raw),
+			Path:     "/auth/saml",
+			HttpOnly: true,
+			Secure:   provider.SecureCookies(),
+			SameSite: sameSite,
+			MaxAge:   int((10 * time.Minute).Seconds()),
+		})
+
+		// 3) Off to the IdP
+		http.Redirect(w, r, dest, http.StatusFound)
+	}
+}
+
+// SAMLACS is the assertion consumer service. It validates the signed
+// response posted by the IdP, provisions or links the user and starts a
+// session; for accounts with 2FA it returns an mfa_token to finish at
+// /login/2fa instead, as Login does. Responses without a matching
+// SP-initiated flow are IdP-initiated and only accepted when
+// -saml-allow-idp-initiated is set.
+func SAMLACS(client *ent.Client, provider *sso.SAML) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+
+		// 1) Recover the flow this response answers, if any
+		requestIDs, next := samlFlowFor(w, r)
+
+		// 2) Validate the assertion
+		claims, err := provider.ParseResponse(r, requestIDs)
+		if err != nil {
+			log.Printf("saml response rejected: %v", err)
+			http.Error(w, "sso login failed", http.StatusUnauthorized)
+			return
+		}
+
+		// 3) Find, link or create the local user
+		u, err := sso.Provision(ctx, client, provider.Issuer(), claims, provider.LinkExisting())
+		if err != nil {
+			log.Printf("failed provisioning saml user %q: %v", claims.Subject, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if u.BannedAt != nil {
+			http.Error(w, "account banned", http.StatusForbidden)
+			return
+		}
+		if !u.Active {
+			http.Error(w, "account deactivated", http.StatusForbidden)
+			return
+		}
+
+		// 4) The IdP stands in for the password only; 2FA still applies
+		if u.TotpEnabled {
+			challenge, _, err := session.CreateChallenge(ctx, client, u.ID, r)
+			if err != nil {
+				log.Printf("failed creating mfa challenge: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			writeMFAChallenge(w, http.StatusOK, challenge)
+			return
+		}
+
+		// 5) Start a session exactly like a password login
+		token, sess, err := session.Create(ctx, client, u.ID, r)
+		if err != nil {
+			log.Printf("failed creating session: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		session.SetCookie(w, token, sess.ExpiresAt)
+
+		http.Redirect(w, r, next, http.StatusSeeOther)
+	}
+}
+
+// samlFlowFor returns the request ID a response posted to the ACS must
+// answer and where to land afterwards, from the flow cookie of the login
+// that started it. The cookie is single-use. Without a cookie whose relay
+// state matches the posted one, the response counts as IdP-initiated:
+// there are no request IDs and the relay state, if any, is where to land.
+func samlFlowFor(w http.ResponseWriter, r *http.Request) ([]string, string) {
+	relayState := r.PostForm.Get("RelayState")
+	if c, err := r.Cookie(samlFlowCookie); err == nil {
+		http.SetCookie(w, &http.Cookie{Name: samlFlowCookie, Path: "/auth/saml", MaxAge: -1})
+		var flow samlFlow
+		raw, err := base64.RawURLEncoding.DecodeString(c.Value)
+		if err == nil && json.Unmarshal(raw, &flow) == nil && flow.RelayState == relayState {
+			return []string{flow.RequestID}, flow.Next
+		}
+	}
+	return nil, safeNext(relayState)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// A response only answers the login whose flow cookie carries the same
+// relay state; anything else is treated as IdP-initiated, which
+// sso.SAML.ParseResponse refuses unless -saml-allow-idp-initiated is set.
+func TestSAMLFlowFor(t *testing.T) {
+	flow, _ := json.Marshal(samlFlow{RequestID: "id-1", RelayState: "relay-1", Next: "/polls/7"})
+	cookie := &http.Cookie{Name: samlFlowCookie, Value: base64.RawURLEncoding.EncodeToString(flow)}
+
+	tests := []struct {
+		name       string
+		relayState string
+		cookie     *http.Cookie
+		wantIDs    []string
+		wantNext   string
+	}{
+		{"matching flow", "relay-1", cookie, []string{"id-1"}, "/polls/7"},
+		{"relay state mismatch", "relay-2", cookie, nil, "/"},
+		{"no relay state", "", cookie, nil, "/"},
+		{"no flow cookie", "relay-1", nil, nil, "/"},
+		{"garbled flow cookie", "relay-1", &http.Cookie{Name: samlFlowCookie, Value: "%%%"}, nil, "/"},
+		{"IdP-initiated with a landing page", "/polls/3", nil, nil, "/polls/3"},
+		{"IdP-initiated with an absolute URL", "https://evil.example.com/", nil, nil, "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"SAMLResponse": {"…"}, "RelayState": {tt.relayState}}
+			r := httptest.NewRequest("POST", "/auth/saml/acs", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != nil {
+				r.AddCookie(tt.cookie)
+			}
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+
+			ids, next := samlFlowFor(w, r)
+			if !slices.Equal(ids, tt.wantIDs) || next != tt.wantNext {
+				t.Errorf("got %v, %q; want %v, %q", ids, next, tt.wantIDs, tt.wantNext)
+			}
+			if tt.cookie != nil && !strings.Contains(w.Header().Get("Set-Cookie"), "Max-Age=0") {
+				t.Errorf("flow cookie not cleared: %q", w.Header().Get("Set-Cookie"))
+			}
+		})
+	}
+}
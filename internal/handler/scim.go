@@ -0,0 +1,808 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/group"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/account"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/scim"
+	"pollAppNew/internal/session"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/julienschmidt/httprouter"
+)
+
+// scimMaxResults caps the page size of SCIM list responses.
+const scimMaxResults = 200
+
+// scimUserAttrs are the User attributes SCIM filters may use.
+var scimUserAttrs = map[string]scim.Attr{
+	"id":           scim.IDAttr(user.FieldID),
+	"username":     scim.StringAttr(user.FieldUsername),
+	"externalid":   scim.StringAttr(user.FieldExternalID),
+	"emails":       scim.StringAttr(user.FieldEmail),
+	"emails.value": scim.StringAttr(user.FieldEmail),
+	"active":       scim.BoolAttr(user.FieldActive),
+}
+
+// scimGroupAttrs are the Group attributes SCIM filters may use.
+var scimGroupAttrs = map[string]scim.Attr{
+	"id":            scim.IDAttr(group.FieldID),
+	"displayname":   scim.StringAttr(group.FieldDisplayName),
+	"externalid":    scim.StringAttr(group.FieldExternalID),
+	"members":       scimMemberAttr,
+	"members.value": scimMemberAttr,
+}
+
+// scimMemberAttr matches groups by member id; only eq applies.
+func scimMemberAttr(op string, value any) (func(*sql.Selector), error) {
+	s, _ := value.(string)
+	id, err := scim.ParseID(s)
+	if op != "eq" || err != nil {
+		return nil, scim.BadRequest(scim.ErrInvalidFilter, `members only supports eq with a user id`)
+	}
+	return group.HasMembersWith(user.IDEQ(id)), nil
+}
+
+// scimLocation returns the absolute URL of a resource for meta.location.
+func scimLocation(r *http.Request, resourceType string, id int) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/scim/v2/" + resourceType + "s/" + scim.FormatID(id)
+}
+
+// scimUserResource renders u, whose groups must be loaded.
+func scimUserResource(r *http.Request, u *ent.User) *scim.User {
+	active := scim.Bool(u.Active)
+	res := &scim.User{
+		Schemas:    []string{scim.SchemaUser},
+		ID:         scim.FormatID(u.ID),
+		ExternalID: u.ExternalID,
+		UserName:   u.Username,
+		Active:     &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      u.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: u.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     scimLocation(r, "User", u.ID),
+		},
+	}
+	if u.Email != nil {
+		res.Emails = []scim.Email{{Value: *u.Email, Type: "work", Primary: true}}
+	}
+	for _, g := range u.Edges.Groups {
+		res.Groups = append(res.Groups, scim.Ref{
+			Value:   scim.FormatID(g.ID),
+			Ref:     scimLocation(r, "Group", g.ID),
+			Display: g.DisplayName,
+		})
+	}
+	return res
+}
+
+// scimGroupResource renders g, with its members if they were loaded.
+func scimGroupResource(r *http.Request, g *ent.Group) *scim.Group {
+	res := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          scim.FormatID(g.ID),
+		ExternalID:  g.ExternalID,
+		DisplayName: g.DisplayName,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      g.CreatedAt.UTC().Format(time.RFC3339),
+			Location:     scimLocation(r, "Group", g.ID),
+		},
+	}
+	for _, u := range g.Edges.Members {
+		res.Members = append(res.Members, scim.Ref{
+			Value:   scim.FormatID(u.ID),
+			Ref:     scimLocation(r, "User", u.ID),
+			Display: u.Username,
+		})
+	}
+	return res
+}
+
+// writeSCIMDecodeError answers a request body that didn't decode.
+func writeSCIMDecodeError(w http.ResponseWriter, err error) {
+	var scimErr *scim.Error
+	if errors.As(err, &scimErr) {
+		scim.WriteError(w, http.StatusBadRequest, scimErr.ScimType, scimErr.Detail)
+		return
+	}
+	scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidSyntax, "invalid JSON payload")
+}
+
+// scimFilter parses ?filter= against attrs. On failure it has already
+// written the response and returns false.
+func scimFilter(w http.ResponseWriter, r *http.Request, attrs map[string]scim.Attr) (func(*sql.Selector), bool) {
+	s := r.URL.Query().Get("filter")
+	if s == "" {
+		return nil, true
+	}
+	f, err := scim.ParseFilter(s)
+	if err == nil {
+		var pred func(*sql.Selector)
+		if pred, err = scim.Compile(f, attrs); err == nil {
+			return pred, true
+		}
+	}
+	scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidFilter, err.Error())
+	return nil, false
+}
+
+// SCIMServiceProviderConfig describes which SCIM features are supported.
+func SCIMServiceProviderConfig() httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		type supported struct {
+			Supported bool `json:"supported"`
+		}
+		scim.WriteJSON(w, http.StatusOK, map[string]any{
+			"schemas":        []string{scim.SchemaSPConfig},
+			"patch":          supported{true},
+			"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         map[string]any{"supported": true, "maxResults": scimMaxResults},
+			"changePassword": supported{true},
+			"sort":           supported{false},
+			"etag":           supported{false},
+			"authenticationSchemes": []map[string]any{{
+				"type":        "oauthbearertoken",
+				"name":        "Personal access token",
+				"description": `an admin's personal access token with the "scim" scope`,
+				"primary":     true,
+			}},
+		})
+	}
+}
+
+// ListSCIMUsers answers GET /scim/v2/Users with ?filter=, ?startIndex= and
+// ?count=. Deleted users are gone; deactivated ones are listed as inactive.
+func ListSCIMUsers(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Parse the query
+		pred, ok := scimFilter(w, r, scimUserAttrs)
+		if !ok {
+			return
+		}
+		offset, limit := scim.Page(r, scimMaxResults)
+		query := client.User.
+			Query().
+			Where(user.DeletedAtIsNil())
+		if pred != nil {
+			query = query.Where(predicate.User(pred))
+		}
+
+		// 2) Count and fetch the page
+		total, err := query.Clone().Count(ctx)
+		if err != nil {
+			log.Printf("failed counting users: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+		users, err := query.
+			Order(ent.Asc(user.FieldID)).
+			Offset(offset).
+			Limit(limit).
+			WithGroups().
+			All(ctx)
+		if err != nil {
+			log.Printf("failed listing users: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+
+		resp := scim.ListResponse{
+			Schemas:      []string{scim.SchemaListResponse},
+			TotalResults: total,
+			StartIndex:   offset + 1,
+			ItemsPerPage: len(users),
+			Resources:    []any{},
+		}
+		for _, u := range users {
+			resp.Resources = append(resp.Resources, scimUserResource(r, u))
+		}
+		scim.WriteJSON(w, http.StatusOK, resp)
+	}
+}
+
+// scimUser loads the live user named by :id with their groups. On failure
+// it has already written the response and returns nil.
+func scimUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client) *ent.User {
+	id, err := scim.ParseID(ps.ByName("id"))
+	if err != nil {
+		scim.WriteError(w, http.StatusNotFound, "", "user not found")
+		return nil
+	}
+	u, err := client.User.
+		Query().
+		Where(user.IDEQ(id), user.DeletedAtIsNil()).
+		WithGroups().
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			scim.WriteError(w, http.StatusNotFound, "", "user not found")
+			return nil
+		}
+		log.Printf("failed loading user %d: %v", id, err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+	return u
+}
+
+// scimWritable reports whether SCIM may change u. Moderators and admins are
+// managed in the app only, so that a provisioning token can't take over or
+// lock out the accounts that administer it. Otherwise it has already
+// written the response.
+func scimWritable(w http.ResponseWriter, u *ent.User) bool {
+	if u.Role != user.RoleUser {
+		scim.WriteError(w, http.StatusForbidden, "", "moderators and admins can't be changed over SCIM")
+		return false
+	}
+	return true
+}
+
+// GetSCIMUser answers GET /scim/v2/Users/:id.
+func GetSCIMUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if u := scimUser(w, r, ps, client); u != nil {
+			scim.WriteJSON(w, http.StatusOK, scimUserResource(r, u))
+		}
+	}
+}
+
+// saveSCIMUser creates a user from in when u is nil, and otherwise replaces
+// u's attributes with in's. Setting active to false deactivates the account
+// and revokes its access, as does setting a new password. On failure it has
+// already written the response and returns nil.
+func saveSCIMUser(w http.ResponseWriter, r *http.Request, client *ent.Client, u *ent.User, in *scim.User) *ent.User {
+	ctx := r.Context()
+
+	// 1) Validate input
+	if in.UserName == "" {
+		scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "userName is required")
+		return nil
+	}
+	if account.Reserved(in.UserName) {
+		scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "userName is reserved")
+		return nil
+	}
+	email := normalizeEmail(in.PrimaryEmail())
+	if email != "" && !mail.ValidAddress(email) {
+		scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "invalid email address")
+		return nil
+	}
+	var hash string
+	if in.Password != "" {
+		var err error
+		if hash, err = password.Hash(in.Password); err != nil {
+			if errors.Is(err, password.ErrTooLong) {
+				scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "password must be at most 72 bytes")
+				return nil
+			}
+			log.Printf("failed hashing password: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return nil
+		}
+	}
+	active := in.Active == nil || bool(*in.Active)
+
+	// 2) Begin transaction
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		log.Printf("failed to start tx: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+	rollback := func() {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Printf("tx rollback error: %v", rbErr)
+		}
+	}
+
+	// 3) Write the attributes; the client is authoritative for addresses
+	var id int
+	if u == nil {
+		create := tx.User.
+			Create().
+			SetUsername(in.UserName).
+			SetNillableExternalID(in.ExternalID).
+			SetPasswordHash(hash).
+			SetActive(active)
+		if email != "" {
+			create.SetEmail(email).SetEmailVerified(true)
+		}
+		var created *ent.User
+		created, err = create.Save(ctx)
+		if err == nil {
+			id = created.ID
+		}
+	} else {
+		id = u.ID
+		update := tx.User.
+			UpdateOneID(u.ID).
+			SetUsername(in.UserName)
+		if in.ExternalID != nil {
+			update.SetExternalID(*in.ExternalID)
+		} else {
+			update.ClearExternalID()
+		}
+		switch {
+		case email == "":
+			update.ClearEmail().SetEmailVerified(false)
+		case u.Email == nil || *u.Email != email:
+			update.SetEmail(email).SetEmailVerified(true)
+		}
+		if hash != "" {
+			update.SetPasswordHash(hash)
+		}
+		if active {
+			update.SetActive(true)
+		}
+		err = update.Exec(ctx)
+		if err == nil && hash != "" {
+			// whoever knew the old password is signed out
+			if _, err = session.RevokeAll(ctx, tx.Client(), u.ID); err == nil {
+				_, err = apitoken.RevokeAll(ctx, tx.Client(), u.ID)
+			}
+		}
+		if err == nil && !active && u.Active {
+			err = account.Deactivate(ctx, tx.Client(), u.ID)
+		}
+	}
+	if err != nil {
+		rollback()
+		if ent.IsConstraintError(err) {
+			scim.WriteError(w, http.StatusConflict, scim.ErrUniqueness, "userName or email already in use")
+			return nil
+		}
+		log.Printf("failed saving scim user: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+
+	// 4) Commit transaction
+	if err := tx.Commit(); err != nil {
+		rollback()
+		log.Printf("failed committing tx: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+
+	saved, err := client.User.
+		Query().
+		Where(user.IDEQ(id)).
+		WithGroups().
+		Only(ctx)
+	if err != nil {
+		log.Printf("failed reloading user %d: %v", id, err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+	return saved
+}
+
+// CreateSCIMUser answers POST /scim/v2/Users.
+func CreateSCIMUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		var in scim.User
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			writeSCIMDecodeError(w, err)
+			return
+		}
+		u := saveSCIMUser(w, r, client, nil, &in)
+		if u == nil {
+			return
+		}
+		res := scimUserResource(r, u)
+		w.Header().Set("Location", res.Meta.Location)
+		scim.WriteJSON(w, http.StatusCreated, res)
+	}
+}
+
+// ReplaceSCIMUser answers PUT /scim/v2/Users/:id.
+func ReplaceSCIMUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		u := scimUser(w, r, ps, client)
+		if u == nil || !scimWritable(w, u) {
+			return
+		}
+		var in scim.User
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			writeSCIMDecodeError(w, err)
+			return
+		}
+		if u = saveSCIMUser(w, r, client, u, &in); u != nil {
+			scim.WriteJSON(w, http.StatusOK, scimUserResource(r, u))
+		}
+	}
+}
+
+// PatchSCIMUser answers PATCH /scim/v2/Users/:id by applying the operations
+// to the user's current representation and saving the result.
+func PatchSCIMUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		u := scimUser(w, r, ps, client)
+		if u == nil || !scimWritable(w, u) {
+			return
+		}
+		var in scim.User
+		if !patchSCIMResource(w, r, scimUserResource(r, u), &in) {
+			return
+		}
+		if u = saveSCIMUser(w, r, client, u, &in); u != nil {
+			scim.WriteJSON(w, http.StatusOK, scimUserResource(r, u))
+		}
+	}
+}
+
+// patchSCIMResource decodes a PatchOp request, applies it to current and
+// decodes the result into out. On failure it has already written the
+// response and returns false.
+func patchSCIMResource(w http.ResponseWriter, r *http.Request, current, out any) bool {
+	var req scim.PatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeSCIMDecodeError(w, err)
+		return false
+	}
+	raw, err := json.Marshal(current)
+	if err != nil {
+		log.Printf("failed encoding scim resource: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return false
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		log.Printf("failed decoding scim resource: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return false
+	}
+	if err := scim.ApplyPatch(doc, req.Operations); err != nil {
+		writeSCIMDecodeError(w, err)
+		return false
+	}
+	if raw, err = json.Marshal(doc); err == nil {
+		err = json.Unmarshal(raw, out)
+	}
+	if err != nil {
+		writeSCIMDecodeError(w, err)
+		return false
+	}
+	return true
+}
+
+// DeleteSCIMUser deprovisions a user. The account is anonymized as when a
+// user deletes it under -account-deletion=anonymize: it loses its access,
+// group memberships and identifying attributes, so its userName and email
+// can be provisioned again, but the row stays so its polls and votes keep
+// their foreign keys.
+func DeleteSCIMUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+		u := scimUser(w, r, ps, client)
+		if u == nil || !scimWritable(w, u) {
+			return
+		}
+
+		// 1) Begin transaction
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+
+		// 2) Anonymize and hide the user
+		if err := account.Anonymize(ctx, tx.Client(), u); err != nil {
+			rollback()
+			log.Printf("failed deprovisioning user %d: %v", u.ID, err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+
+		// 3) Commit transaction
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// withMembers reports whether the request wants group members rendered;
+// clients syncing large groups ask for ?excludedAttributes=members.
+func withMembers(r *http.Request) bool {
+	for _, a := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if scim.AttrName(strings.TrimSpace(a)) == "members" {
+			return false
+		}
+	}
+	return true
+}
+
+// ListSCIMGroups answers GET /scim/v2/Groups with ?filter=, ?startIndex=
+// and ?count=.
+func ListSCIMGroups(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Parse the query
+		pred, ok := scimFilter(w, r, scimGroupAttrs)
+		if !ok {
+			return
+		}
+		offset, limit := scim.Page(r, scimMaxResults)
+		query := client.Group.Query()
+		if pred != nil {
+			query = query.Where(predicate.Group(pred))
+		}
+
+		// 2) Count and fetch the page
+		total, err := query.Clone().Count(ctx)
+		if err != nil {
+			log.Printf("failed counting groups: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+		query = query.
+			Order(ent.Asc(group.FieldID)).
+			Offset(offset).
+			Limit(limit)
+		if withMembers(r) {
+			query = query.WithMembers()
+		}
+		groups, err := query.All(ctx)
+		if err != nil {
+			log.Printf("failed listing groups: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+
+		resp := scim.ListResponse{
+			Schemas:      []string{scim.SchemaListResponse},
+			TotalResults: total,
+			StartIndex:   offset + 1,
+			ItemsPerPage: len(groups),
+			Resources:    []any{},
+		}
+		for _, g := range groups {
+			resp.Resources = append(resp.Resources, scimGroupResource(r, g))
+		}
+		scim.WriteJSON(w, http.StatusOK, resp)
+	}
+}
+
+// scimGroup loads the group named by :id, with members unless excluded.
+// On failure it has already written the response and returns nil.
+func scimGroup(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client, members bool) *ent.Group {
+	id, err := scim.ParseID(ps.ByName("id"))
+	if err != nil {
+		scim.WriteError(w, http.StatusNotFound, "", "group not found")
+		return nil
+	}
+	query := client.Group.
+		Query().
+		Where(group.IDEQ(id))
+	if members {
+		query = query.WithMembers()
+	}
+	g, err := query.Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			scim.WriteError(w, http.StatusNotFound, "", "group not found")
+			return nil
+		}
+		log.Printf("failed loading group %d: %v", id, err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+	return g
+}
+
+// GetSCIMGroup answers GET /scim/v2/Groups/:id.
+func GetSCIMGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if g := scimGroup(w, r, ps, client, withMembers(r)); g != nil {
+			scim.WriteJSON(w, http.StatusOK, scimGroupResource(r, g))
+		}
+	}
+}
+
+// saveSCIMGroup creates a group from in when g is nil, and otherwise
+// replaces g's name and members with in's. On failure it has already
+// written the response and returns nil.
+func saveSCIMGroup(w http.ResponseWriter, r *http.Request, client *ent.Client, g *ent.Group, in *scim.Group) *ent.Group {
+	ctx := r.Context()
+
+	// 1) Validate input; every member must be a live user
+	if in.DisplayName == "" {
+		scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "displayName is required")
+		return nil
+	}
+	ids := make([]int, 0, len(in.Members))
+	for _, m := range in.Members {
+		id, err := scim.ParseID(m.Value)
+		if err != nil {
+			scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "unknown member "+m.Value)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	ids = uniqueInts(ids)
+	if len(ids) > 0 {
+		n, err := client.User.
+			Query().
+			Where(user.IDIn(ids...), user.DeletedAtIsNil()).
+			Count(ctx)
+		if err != nil {
+			log.Printf("failed checking group members: %v", err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return nil
+		}
+		if n != len(ids) {
+			scim.WriteError(w, http.StatusBadRequest, scim.ErrInvalidValue, "members must be existing users")
+			return nil
+		}
+	}
+
+	// 2) Write the group
+	var (
+		id  int
+		err error
+	)
+	if g == nil {
+		var created *ent.Group
+		created, err = client.Group.
+			Create().
+			SetDisplayName(in.DisplayName).
+			SetNillableExternalID(in.ExternalID).
+			AddMemberIDs(ids...).
+			Save(ctx)
+		if err == nil {
+			id = created.ID
+		}
+	} else {
+		id = g.ID
+		update := client.Group.
+			UpdateOneID(g.ID).
+			SetDisplayName(in.DisplayName).
+			ClearMembers().
+			AddMemberIDs(ids...)
+		if in.ExternalID != nil {
+			update.SetExternalID(*in.ExternalID)
+		} else {
+			update.ClearExternalID()
+		}
+		err = update.Exec(ctx)
+	}
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			scim.WriteError(w, http.StatusConflict, scim.ErrUniqueness, "displayName already in use")
+			return nil
+		}
+		log.Printf("failed saving scim group: %v", err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+
+	saved, err := client.Group.
+		Query().
+		Where(group.IDEQ(id)).
+		WithMembers().
+		Only(ctx)
+	if err != nil {
+		log.Printf("failed reloading group %d: %v", id, err)
+		scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+		return nil
+	}
+	return saved
+}
+
+// uniqueInts returns ids without duplicates, in order.
+func uniqueInts(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	out := ids[:0:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// CreateSCIMGroup answers POST /scim/v2/Groups.
+func CreateSCIMGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		var in scim.Group
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			writeSCIMDecodeError(w, err)
+			return
+		}
+		g := saveSCIMGroup(w, r, client, nil, &in)
+		if g == nil {
+			return
+		}
+		res := scimGroupResource(r, g)
+		w.Header().Set("Location", res.Meta.Location)
+		scim.WriteJSON(w, http.StatusCreated, res)
+	}
+}
+
+// ReplaceSCIMGroup answers PUT /scim/v2/Groups/:id.
+func ReplaceSCIMGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		g := scimGroup(w, r, ps, client, false)
+		if g == nil {
+			return
+		}
+		var in scim.Group
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			writeSCIMDecodeError(w, err)
+			return
+		}
+		if g = saveSCIMGroup(w, r, client, g, &in); g != nil {
+			scim.WriteJSON(w, http.StatusOK, scimGroupResource(r, g))
+		}
+	}
+}
+
+// PatchSCIMGroup answers PATCH /scim/v2/Groups/:id, typically adding or
+// removing members.
+func PatchSCIMGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		g := scimGroup(w, r, ps, client, true)
+		if g == nil {
+			return
+		}
+		var in scim.Group
+		if !patchSCIMResource(w, r, scimGroupResource(r, g), &in) {
+			return
+		}
+		if g = saveSCIMGroup(w, r, client, g, &in); g != nil {
+			scim.WriteJSON(w, http.StatusOK, scimGroupResource(r, g))
+		}
+	}
+}
+
+// DeleteSCIMGroup answers DELETE /scim/v2/Groups/:id. Members are unaffected.
+func DeleteSCIMGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		id, err := scim.ParseID(ps.ByName("id"))
+		if err != nil {
+			scim.WriteError(w, http.StatusNotFound, "", "group not found")
+			return
+		}
+		if err := client.Group.DeleteOneID(id).Exec(r.Context()); err != nil {
+			if ent.IsNotFound(err) {
+				scim.WriteError(w, http.StatusNotFound, "", "group not found")
+				return
+			}
+			log.Printf("failed deleting group %d: %v", id, err)
+			scim.WriteError(w, http.StatusInternalServerError, "", "internal server error")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/apitoken"
+	"pollAppNew/internal/password"
+	"pollAppNew/internal/session"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// callID runs h on a request for the resource id with a JSON body.
+func callID(h httprouter.Handle, method, path string, id int, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path+"/"+strconv.Itoa(id), strings.NewReader(body))
+	h(w, r, httprouter.Params{{Key: "id", Value: strconv.Itoa(id)}})
+	return w
+}
+
+func TestSCIMLeavesPrivilegedUsersAlone(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	admin := client.User.Create().SetUsername("root").SetEmail("root@example.com").SetRole(user.RoleAdmin).SaveX(ctx)
+	mod := client.User.Create().SetUsername("mod").SetRole(user.RoleModerator).SaveX(ctx)
+
+	replace, patch, del := ReplaceSCIMUser(client), PatchSCIMUser(client), DeleteSCIMUser(client)
+	for _, u := range []int{admin.ID, mod.ID} {
+		if w := callID(replace, "PUT", "/scim/v2/Users", u, `{"userName":"root","emails":[{"value":"evil@example.com"}],"password":"taken over"}`); w.Code != http.StatusForbidden {
+			t.Errorf("PUT user %d: %d %s", u, w.Code, w.Body)
+		}
+		if w := callID(patch, "PATCH", "/scim/v2/Users", u, `{"Operations":[{"op":"replace","path":"active","value":false}]}`); w.Code != http.StatusForbidden {
+			t.Errorf("PATCH user %d: %d %s", u, w.Code, w.Body)
+		}
+		if w := callID(del, "DELETE", "/scim/v2/Users", u, ""); w.Code != http.StatusForbidden {
+			t.Errorf("DELETE user %d: %d %s", u, w.Code, w.Body)
+		}
+	}
+	got := client.User.GetX(ctx, admin.ID)
+	if *got.Email != "root@example.com" || got.PasswordHash != "" || !got.Active || got.DeletedAt != nil {
+		t.Fatalf("admin changed over SCIM: %+v", got)
+	}
+}
+
+func TestSCIMPasswordSignsOut(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	u := client.User.Create().SetUsername("alice").SaveX(ctx)
+	if _, _, err := session.Create(ctx, client, u.ID, httptest.NewRequest("GET", "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := apitoken.Create(ctx, client, u.ID, "ci", []string{"polls:read"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	w := callID(PatchSCIMUser(client), "PATCH", "/scim/v2/Users", u.ID, `{"Operations":[{"op":"replace","path":"password","value":"new secret"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PATCH: %d %s", w.Code, w.Body)
+	}
+	if ok, _ := password.Verify(client.User.GetX(ctx, u.ID).PasswordHash, "new secret"); !ok {
+		t.Fatal("password not set")
+	}
+	if n := client.Session.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d sessions left", n)
+	}
+	if n := client.AccessToken.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d access tokens left", n)
+	}
+}
+
+func TestSCIMReprovision(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	create := CreateSCIMUser(client)
+	body := `{"userName":"alice","externalId":"e-1","emails":[{"value":"alice@example.com","primary":true}]}`
+
+	w := call(create, "POST", "/scim/v2/Users", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+	first := client.User.Query().Where(user.UsernameEQ("alice")).OnlyX(ctx)
+	client.Identity.Create().SetProvider("idp").SetSubject("s-1").SetUser(first).ExecX(ctx)
+
+	if w := callID(DeleteSCIMUser(client), "DELETE", "/scim/v2/Users", first.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("delete: %d %s", w.Code, w.Body)
+	}
+	gone := client.User.GetX(ctx, first.ID)
+	if gone.Username == "alice" || gone.Email != nil || gone.ExternalID != nil || gone.Active || gone.DeletedAt == nil {
+		t.Fatalf("deprovisioned user keeps its attributes: %+v", gone)
+	}
+	if n := client.Identity.Query().CountX(ctx); n != 0 {
+		t.Fatalf("%d identities left; an SSO login would still reach the old account", n)
+	}
+
+	// the same person can be provisioned again
+	if w := call(create, "POST", "/scim/v2/Users", body); w.Code != http.StatusCreated {
+		t.Fatalf("re-create: %d %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/session"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type sessionResponse struct {
+	ID         int       `json:"id"`
+	Kind       string    `json:"kind"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// ListSessions returns the caller's active sessions, flagging the current one.
+func ListSessions(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Current session, resolved by auth.Middleware
+		sess := auth.SessionFromContext(ctx)
+
+		// 2) Load the user's sessions
+		sessions, err := session.ListForUser(ctx, client, sess.UserID)
+		if err != nil {
+			log.Printf("failed listing sessions: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 3) Build response
+		resp := make([]sessionResponse, len(sessions))
+		for i, s := range sessions {
+			resp[i] = sessionResponse{
+				ID:         s.ID,
+				Kind:       s.Kind.String(),
+				UserAgent:  s.UserAgent,
+				IP:         s.IP,
+				CreatedAt:  s.CreatedAt,
+				LastSeenAt: s.LastSeenAt,
+				ExpiresAt:  s.ExpiresAt,
+				Current:    s.ID == sess.ID,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// RevokeSession revokes one of the caller's sessions by ID.
+func RevokeSession(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Current session, resolved by auth.Middleware
+		sess := auth.SessionFromContext(ctx)
+
+		// 2) Parse session ID
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			http.Error(w, "invalid session id", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Delete it, but only if it belongs to the caller
+		if err := session.RevokeForUser(ctx, client, sess.UserID, id); err != nil {
+			if errors.Is(err, session.ErrNotFound) {
+				http.Error(w, "session not found", http.StatusNotFound)
+				return
+			}
+			log.Printf("failed revoking session %d: %v", id, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Revoking the current session is a logout
+		if id == sess.ID {
+			session.ClearCookie(w)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RevokeOtherSessions revokes every session of the caller except the current one.
+func RevokeOtherSessions(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Current session, resolved by auth.Middleware
+		sess := auth.SessionFromContext(ctx)
+
+		// 2) Delete everything but this session
+		n, err := session.RevokeOthers(ctx, client, sess.UserID, sess.ID)
+		if err != nil {
+			log.Printf("failed revoking sessions: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"revoked": n,
+		}); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/internal/authn"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"pollAppNew/internal/token"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// IssueToken exchanges a username/password ("password" grant) or a refresh
+// token ("refresh_token" grant) for a short-lived bearer access token and a
+// new single-use refresh token. For accounts with 2FA the password grant
+// answers 403 mfa_required with an mfa_token, which the "mfa" grant redeems
+// together with a TOTP or recovery code.
+func IssueToken(client *ent.Client, authenticator authn.Authenticator, keys *token.KeySet, limits *ratelimit.Limits) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Decode JSON body
+		var req struct {
+			GrantType    string `json:"grant_type"`
+			Username     string `json:"username"`
+			Password     string `json:"password"`
+			RefreshToken string `json:"refresh_token"`
+			MFAToken     string `json:"mfa_token"`
+			Code         string `json:"code"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Obtain a refresh session for the grant
+		var (
+			refresh string
+			sess    *ent.Session
+		)
+		switch req.GrantType {
+		case "password":
+			if sso.SSOOnly() {
+				http.Error(w, "password login is disabled; sign in with SSO", http.StatusForbidden)
+				return
+			}
+			u, err := checkLogin(r, authenticator, limits, req.Username, req.Password)
+			if err != nil {
+				writeLoginError(w, err)
+				return
+			}
+			if u.TotpEnabled {
+				challenge, _, err := session.CreateChallenge(ctx, client, u.ID, r)
+				if err != nil {
+					log.Printf("failed creating mfa challenge: %v", err)
+					http.Error(w, "internal server error", http.StatusInternalServerError)
+					return
+				}
+				writeMFAChallenge(w, http.StatusForbidden, challenge)
+				return
+			}
+			refresh, sess, err = session.CreateRefresh(ctx, client, u.ID, r)
+			if err != nil {
+				log.Printf("failed creating refresh session: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+		case "mfa":
+			u := redeemChallenge(w, r, client, limits, req.MFAToken, req.Code)
+			if u == nil {
+				return
+			}
+			var err error
+			refresh, sess, err = session.CreateRefresh(ctx, client, u.ID, r)
+			if err != nil {
+				log.Printf("failed creating refresh session: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+		case "refresh_token":
+			old, err := session.LookupRefresh(ctx, client, req.RefreshToken)
+			if err != nil {
+				if errors.Is(err, session.ErrInvalid) {
+					http.Error(w, "invalid refresh token", http.StatusUnauthorized)
+					return
+				}
+				log.Printf("failed looking up refresh token: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			refresh, sess, err = session.Rotate(ctx, client, old)
+			if err != nil {
+				if errors.Is(err, session.ErrInvalid) {
+					http.Error(w, "invalid refresh token", http.StatusUnauthorized)
+					return
+				}
+				log.Printf("failed rotating refresh token: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+		default:
+			http.Error(w, `grant_type must be "password", "mfa" or "refresh_token"`, http.StatusBadRequest)
+			return
+		}
+
+		// 3) Sign the access token
+		access, exp, err := keys.Issue(sess.UserID, sess.ID)
+		if err != nil {
+			log.Printf("failed signing access token: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Return both tokens; they must never be cached
+		resp := struct {
+			AccessToken  string `json:"access_token"`
+			TokenType    string `json:"token_type"`
+			ExpiresIn    int    `json:"expires_in"`
+			RefreshToken string `json:"refresh_token"`
+		}{
+			AccessToken:  access,
+			TokenType:    "Bearer",
+			ExpiresIn:    int(time.Until(exp).Seconds()),
+			RefreshToken: refresh,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// JWKS publishes the public keys access tokens can be verified with.
+func JWKS(keys *token.KeySet) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		resp := struct {
+			Keys []token.JWK `json:"keys"`
+		}{
+			Keys: keys.JWKS(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
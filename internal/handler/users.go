@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/user"
+	"pollAppNew/ent/vote"
+	"pollAppNew/internal/dto"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+// inDirectory matches the users the public directory shows: anonymized
+// accounts of deleted users and deactivated accounts are left out.
+var inDirectory = user.And(user.DeletedAtIsNil(), user.Active(true))
+
+// ListUsers serves the public user directory: users matching ?q= in their
+// username, ?limit= at a time, continuing after ?cursor=.
+func ListUsers(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		q := r.URL.Query()
+
+		// 1) Parse paging parameters
+		limit := defaultUserPageSize
+		if s := q.Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 || n > maxUserPageSize {
+				http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		after := 0
+		if s := q.Get("cursor"); s != "" {
+			n, err := decodeCursor(s)
+			if err != nil {
+				http.Error(w, "invalid cursor", http.StatusBadRequest)
+				return
+			}
+			after = n
+		}
+
+		// 2) Fetch one extra row to learn whether another page follows
+		query := client.User.
+			Query().
+			Where(inDirectory, user.IDGT(after))
+		if term := q.Get("q"); term != "" {
+			query = query.Where(user.UsernameContainsFold(term))
+		}
+		users, err := query.
+			Order(ent.Asc(user.FieldID)).
+			Limit(limit + 1).
+			All(ctx)
+		if err != nil {
+			log.Printf("failed querying users: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		var page dto.UserPage
+		if len(users) > limit {
+			users = users[:limit]
+			page.NextCursor = encodeCursor(users[limit-1].ID)
+		}
+
+		// 3) Attach activity stats
+		stats, err := userStats(ctx, client, users)
+		if err != nil {
+			log.Printf("failed loading user stats: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		page.Users = make([]dto.PublicUser, len(users))
+		for i, u := range users {
+			page.Users[i] = dto.NewPublicUser(u, stats[u.ID])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// GetUser returns the public profile of a user by ID, provided the
+// directory lists them.
+func GetUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Parse user ID
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			http.Error(w, "invalid user id", http.StatusBadRequest)
+			return
+		}
+
+		// 2) Load the user and their stats
+		u, err := client.User.
+			Query().
+			Where(user.ID(id), inDirectory).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				http.Error(w, "user not found", http.StatusNotFound)
+				return
+			}
+			log.Printf("failed loading user %d: %v", id, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		stats, err := userStats(ctx, client, []*ent.User{u})
+		if err != nil {
+			log.Printf("failed loading user stats: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dto.NewPublicUser(u, stats[u.ID])); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// userStats counts the polls created and ballots cast by each of users, in
+// two grouped queries rather than two per user.
+func userStats(ctx context.Context, client *ent.Client, users []*ent.User) (map[int]dto.UserStats, error) {
+	ids := make([]int, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	stats := make(map[int]dto.UserStats, len(users))
+
+	var polls []struct {
+		CreatorID int `json:"creator_id"`
+		Count     int `json:"count"`
+	}
+	if err := client.Poll.
+		Query().
+		Where(poll.CreatorIDIn(ids...)).
+		GroupBy(poll.FieldCreatorID).
+		Aggregate(ent.As(ent.Count(), "count")).
+		Scan(ctx, &polls); err != nil {
+		return nil, err
+	}
+	for _, c := range polls {
+		s := stats[c.CreatorID]
+		s.PollsCreated = c.Count
+		stats[c.CreatorID] = s
+	}
+
+	// a ballot is one vote row per selected, ranked or rated option, so
+	// count the distinct polls each user voted in
+	var ballots []struct {
+		UserID int `json:"user_id"`
+		PollID int `json:"poll_id"`
+	}
+	if err := client.Vote.
+		Query().
+		Where(vote.UserIDIn(ids...)).
+		GroupBy(vote.FieldUserID, vote.FieldPollID).
+		Scan(ctx, &ballots); err != nil {
+		return nil, err
+	}
+	for _, b := range ballots {
+		s := stats[b.UserID]
+		s.VotesCast++
+		stats[b.UserID] = s
+	}
+	return stats, nil
+}
+
+// encodeCursor and decodeCursor make the keyset cursor opaque to clients.
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+func decodeCursor(s string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(b))
+}
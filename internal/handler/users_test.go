@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"pollAppNew/ent"
+)
+
+// A ranked or score ballot stores one vote per option, but counts once.
+func TestUserStatsCountsBallots(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	alice := client.User.Create().SetUsername("alice").SaveX(ctx)
+	bob := client.User.Create().SetUsername("bob").SaveX(ctx)
+
+	for range 2 {
+		p := client.Poll.Create().SetTitle("t").SetCreatorID(alice.ID).SaveX(ctx)
+		for i := range 3 {
+			o := client.PollOption.Create().SetPollID(p.ID).SetText("o").SaveX(ctx)
+			client.Vote.Create().SetUserID(bob.ID).SetPollID(p.ID).SetOptionID(o.ID).SetPosition(i).ExecX(ctx)
+		}
+	}
+
+	stats, err := userStats(ctx, client, []*ent.User{alice, bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := stats[alice.ID]; s.PollsCreated != 2 || s.VotesCast != 0 {
+		t.Errorf("alice: %+v", s)
+	}
+	if s := stats[bob.ID]; s.PollsCreated != 0 || s.VotesCast != 2 {
+		t.Errorf("bob: %+v, want 2 votes cast", s)
+	}
+}
+
+// A profile is served only for users the directory would list.
+func TestGetUserHidesUnlistedUsers(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	alice := client.User.Create().SetUsername("alice").SaveX(ctx)
+	gone := client.User.Create().SetUsername("deleted-2").SetDeletedAt(time.Now()).SaveX(ctx)
+	off := client.User.Create().SetUsername("carol").SetActive(false).SaveX(ctx)
+
+	h := GetUser(client)
+	for _, tc := range []struct {
+		id   int
+		want int
+	}{
+		{alice.ID, http.StatusOK},
+		{gone.ID, http.StatusNotFound},
+		{off.ID, http.StatusNotFound},
+		{off.ID + 1, http.StatusNotFound},
+	} {
+		if rec := callID(h, "GET", "/users", tc.id, ""); rec.Code != tc.want {
+			t.Errorf("user %d: status %d, want %d", tc.id, rec.Code, tc.want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/group"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/authz"
+	"slices"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// SetVisibility changes who can find a poll: public, unlisted or private.
+func SetVisibility(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Decode and validate the new visibility
+		var req struct {
+			Visibility string `json:"visibility"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		visibility := poll.Visibility(req.Visibility)
+		if poll.VisibilityValidator(visibility) != nil {
+			http.Error(w, "invalid visibility", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Store it
+		p, err := client.Poll.
+			UpdateOne(p).
+			SetVisibility(visibility).
+			Save(r.Context())
+		if err != nil {
+			log.Printf("failed updating poll visibility: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":         p.ID,
+			"visibility": p.Visibility,
+			"updated_at": p.UpdatedAt,
+		}); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// ListInvites lists the users and groups invited to a poll. Invites only
+// matter while the poll is private, but are kept whatever its visibility.
+func ListInvites(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Return its invites
+		writeInvites(r.Context(), w, p)
+	}
+}
+
+// AddInvites invites users and groups, by ID, to a poll. The body holds
+// user_ids and group_ids; inviting someone twice is not an error.
+func AddInvites(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Decode the invitees
+		var req struct {
+			UserIDs  []int `json:"user_ids"`
+			GroupIDs []int `json:"group_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		if len(req.UserIDs) == 0 && len(req.GroupIDs) == 0 {
+			http.Error(w, "user_ids or group_ids is required", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Keep only those not invited yet, checking that they exist
+		userIDs, err := client.User.
+			Query().
+			Where(user.IDIn(req.UserIDs...)).
+			IDs(ctx)
+		if err != nil {
+			log.Printf("error querying users: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if len(userIDs) != len(distinct(req.UserIDs)) {
+			http.Error(w, "invalid user id", http.StatusBadRequest)
+			return
+		}
+		groupIDs, err := client.Group.
+			Query().
+			Where(group.IDIn(req.GroupIDs...)).
+			IDs(ctx)
+		if err != nil {
+			log.Printf("error querying groups: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if len(groupIDs) != len(distinct(req.GroupIDs)) {
+			http.Error(w, "invalid group id", http.StatusBadRequest)
+			return
+		}
+		invitedUsers, err := p.QueryInvitedUsers().IDs(ctx)
+		if err != nil {
+			log.Printf("error querying invites: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		invitedGroups, err := p.QueryInvitedGroups().IDs(ctx)
+		if err != nil {
+			log.Printf("error querying invites: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Store the new invites
+		if err := client.Poll.
+			UpdateOne(p).
+			AddInvitedUserIDs(without(userIDs, invitedUsers)...).
+			AddInvitedGroupIDs(without(groupIDs, invitedGroups)...).
+			Exec(ctx); err != nil {
+			// a concurrent request invited some of them first
+			if ent.IsConstraintError(err) {
+				http.Error(w, "invites were changed concurrently", http.StatusConflict)
+				return
+			}
+			log.Printf("failed adding invites: %v", err)
+			http.Error(w, "could not add invites", http.StatusInternalServerError)
+			return
+		}
+
+		// 5) Return the poll's invites
+		writeInvites(ctx, w, p)
+	}
+}
+
+// UninviteUser withdraws a user's invite to a poll.
+func UninviteUser(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Parse the user ID
+		userID, err := strconv.Atoi(ps.ByName("userId"))
+		if err != nil {
+			http.Error(w, "invalid user id", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Remove the invite
+		invited, err := p.QueryInvitedUsers().Where(user.IDEQ(userID)).Exist(r.Context())
+		if err != nil {
+			log.Printf("error querying invites: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !invited {
+			http.Error(w, "invite not found", http.StatusNotFound)
+			return
+		}
+		if err := client.Poll.UpdateOne(p).RemoveInvitedUserIDs(userID).Exec(r.Context()); err != nil {
+			log.Printf("failed removing invite: %v", err)
+			http.Error(w, "could not remove invite", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// UninviteGroup withdraws a group's invite to a poll.
+func UninviteGroup(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		// 1) Load the poll and check the caller may edit it
+		p := managedPoll(w, r, ps, client, authz.PollEditAny)
+		if p == nil {
+			return
+		}
+
+		// 2) Parse the group ID
+		groupID, err := strconv.Atoi(ps.ByName("groupId"))
+		if err != nil {
+			http.Error(w, "invalid group id", http.StatusBadRequest)
+			return
+		}
+
+		// 3) Remove the invite
+		invited, err := p.QueryInvitedGroups().Where(group.IDEQ(groupID)).Exist(r.Context())
+		if err != nil {
+			log.Printf("error querying invites: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !invited {
+			http.Error(w, "invite not found", http.StatusNotFound)
+			return
+		}
+		if err := client.Poll.UpdateOne(p).RemoveInvitedGroupIDs(groupID).Exec(r.Context()); err != nil {
+			log.Printf("failed removing invite: %v", err)
+			http.Error(w, "could not remove invite", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// writeInvites answers with the users and groups invited to p.
+func writeInvites(ctx context.Context, w http.ResponseWriter, p *ent.Poll) {
+	users, err := p.QueryInvitedUsers().Order(ent.Asc(user.FieldID)).All(ctx)
+	if err != nil {
+		log.Printf("error querying invites: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	groups, err := p.QueryInvitedGroups().Order(ent.Asc(group.FieldID)).All(ctx)
+	if err != nil {
+		log.Printf("error querying invites: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	type userResp struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	}
+	type groupResp struct {
+		ID          int    `json:"id"`
+		DisplayName string `json:"display_name"`
+	}
+	resp := struct {
+		PollID     int             `json:"poll_id"`
+		Visibility poll.Visibility `json:"visibility"`
+		Users      []userResp      `json:"users"`
+		Groups     []groupResp     `json:"groups"`
+	}{
+		PollID:     p.ID,
+		Visibility: p.Visibility,
+		Users:      make([]userResp, len(users)),
+		Groups:     make([]groupResp, len(groups)),
+	}
+	for i, u := range users {
+		resp.Users[i] = userResp{ID: u.ID, Username: u.Username}
+	}
+	for i, g := range groups {
+		resp.Groups[i] = groupResp{ID: g.ID, DisplayName: g.DisplayName}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
+
+// checkVisible reports whether u may see p. Otherwise it has already
+// answered with msg and status, as for a poll that doesn't exist, so that
+// drafts and private polls aren't disclosed.
+func checkVisible(ctx context.Context, w http.ResponseWriter, client *ent.Client, u *ent.User, p *ent.Poll, msg string, status int) bool {
+	ok, err := authz.CanViewPoll(ctx, client, u, p)
+	if err != nil {
+		log.Printf("error checking poll visibility: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return false
+	}
+	if !ok {
+		http.Error(w, msg, status)
+		return false
+	}
+	return true
+}
+
+// checkManaged reports whether u owns p or holds perm. Otherwise it has
+// already answered 404 if u may not see p, so as not to give away that it
+// exists, or 403 if u may.
+func checkManaged(ctx context.Context, w http.ResponseWriter, client *ent.Client, u *ent.User, p *ent.Poll, perm authz.Permission) bool {
+	if authz.CanManagePoll(u, p, perm) {
+		return true
+	}
+	if checkVisible(ctx, w, client, u, p, "poll not found", http.StatusNotFound) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}
+	return false
+}
+
+// distinct returns ids without repeats.
+func distinct(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// without returns the ids not in drop.
+func without(ids, drop []int) []int {
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(drop, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pollAppNew/ent/poll"
+	"pollAppNew/internal/authz"
+)
+
+// Only those who may see a poll learn that managing it is forbidden.
+func TestCheckManaged(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	owner := client.User.Create().SetUsername("owner").SaveX(ctx)
+	stranger := client.User.Create().SetUsername("stranger").SaveX(ctx)
+	public := client.Poll.Create().SetTitle("t").SetCreatorID(owner.ID).SaveX(ctx)
+	private := client.Poll.Create().SetTitle("t").SetCreatorID(owner.ID).SetVisibility(poll.VisibilityPrivate).SaveX(ctx)
+	draft := client.Poll.Create().SetTitle("t").SetCreatorID(owner.ID).SetStatus(poll.StatusDraft).SaveX(ctx)
+
+	for _, p := range []int{public.ID, private.ID, draft.ID} {
+		w := httptest.NewRecorder()
+		if !checkManaged(ctx, w, client, owner, client.Poll.GetX(ctx, p), authz.PollEditAny) {
+			t.Errorf("owner refused poll %d: %d", p, w.Code)
+		}
+	}
+	tests := []struct {
+		name string
+		p    int
+		want int
+	}{
+		{"public", public.ID, http.StatusForbidden},
+		{"private", private.ID, http.StatusNotFound},
+		{"draft", draft.ID, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if checkManaged(ctx, w, client, stranger, client.Poll.GetX(ctx, tt.p), authz.PollEditAny) || w.Code != tt.want {
+				t.Errorf("got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"pollAppNew/ent"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/lifecycle"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ChangeVote replaces the caller's ballot on a poll that allows vote
+// changes. The body is a ballotRequest, as for Vote.
+func ChangeVote(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 0) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		userID := auth.UserFromContext(ctx).ID
+
+		// 1) Only open polls that allow changes take revisions
+		p := revisablePoll(w, r, ps, client)
+		if p == nil {
+			return
+		}
+
+		// 2) Decode and validate the new ballot
+		var req ballotRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+		optionIDs, ok := checkBallot(ctx, w, client, p, &req)
+		if !ok {
+			return
+		}
+
+		// 3) Swap the old ballot for the new one and record the change
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+		voters, err := deleteBallots(ctx, tx, p.ID, vote.UserIDEQ(userID))
+		if err != nil {
+			rollback()
+			log.Printf("failed deleting votes: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if len(voters) == 0 {
+			rollback()
+			http.Error(w, "user has not voted on this poll", http.StatusNotFound)
+			return
+		}
+		votes, err := storeBallot(ctx, tx, userID, p, optionIDs, req.Scores, votehistory.ActionChange)
+		if err != nil {
+			rollback()
+			// a concurrent change from the same voter won the unique index
+			if ent.IsConstraintError(err) {
+				http.Error(w, "ballot was changed concurrently", http.StatusConflict)
+				return
+			}
+			log.Printf("failed changing vote: %v", err)
+			http.Error(w, "could not change vote", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 4) Return the new ballot with updated results
+		writeBallot(ctx, w, client, p, http.StatusOK, votes)
+	}
+}
+
+// RetractVote withdraws the caller's ballot on a poll that allows vote
+// changes. The voter may cast a new one afterwards.
+func RetractVote(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		// 0) Caller is resolved by auth.Middleware; RequireAuth guarantees one
+		userID := auth.UserFromContext(ctx).ID
+
+		// 1) Only open polls that allow changes take retractions
+		p := revisablePoll(w, r, ps, client)
+		if p == nil {
+			return
+		}
+
+		// 2) Delete the ballot and record the retraction
+		tx, err := client.Tx(ctx)
+		if err != nil {
+			log.Printf("failed to start tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		rollback := func() {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Printf("tx rollback error: %v", rbErr)
+			}
+		}
+		voters, err := deleteBallots(ctx, tx, p.ID, vote.UserIDEQ(userID))
+		if err != nil {
+			rollback()
+			log.Printf("failed deleting votes: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if len(voters) == 0 {
+			rollback()
+			http.Error(w, "user has not voted on this poll", http.StatusNotFound)
+			return
+		}
+		if err := recordBallot(ctx, tx, userID, p.ID, votehistory.ActionRetract, []int{}, nil); err != nil {
+			rollback()
+			log.Printf("failed recording retraction: %v", err)
+			http.Error(w, "could not retract vote", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			rollback()
+			log.Printf("failed committing tx: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 3) Success
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetVoteHistory lists every ballot cast, changed or retracted on a poll,
+// oldest first. Voters see their own entries; the poll's creator and
+// roles allowed to edit any poll see everyone's.
+func GetVoteHistory(client *ent.Client) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+		u := auth.UserFromContext(ctx)
+
+		// 1) Parse poll ID and load the poll
+		pollID, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			http.Error(w, "invalid poll id", http.StatusBadRequest)
+			return
+		}
+		p, err := client.Poll.Get(ctx, pollID)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				http.Error(w, "poll not found", http.StatusNotFound)
+			} else {
+				log.Printf("query poll error: %v", err)
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+			return
+		}
+		if !checkVisible(ctx, w, client, u, p, "poll not found", http.StatusNotFound) {
+			return
+		}
+
+		// 2) Query the history, only the caller's unless they manage the poll
+		q := client.VoteHistory.
+			Query().
+			Where(votehistory.PollIDEQ(p.ID))
+		if !authz.CanManagePoll(u, p, authz.PollEditAny) {
+			q = q.Where(votehistory.UserIDEQ(u.ID))
+		}
+		entries, err := q.
+			Order(ent.Asc(votehistory.FieldCreatedAt), ent.Asc(votehistory.FieldID)).
+			All(ctx)
+		if err != nil {
+			log.Printf("error querying vote history: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// 3) Build and return response
+		type entryResp struct {
+			UserID    int                `json:"user_id"`
+			Action    votehistory.Action `json:"action"`
+			OptionIDs []int              `json:"option_ids"`
+			Scores    map[int]int        `json:"scores,omitempty"`
+			CreatedAt time.Time          `json:"created_at"`
+		}
+		resp := make([]entryResp, len(entries))
+		for i, e := range entries {
+			resp[i] = entryResp{
+				UserID:    e.UserID,
+				Action:    e.Action,
+				OptionIDs: e.OptionIds,
+				Scores:    e.Scores,
+				CreatedAt: e.CreatedAt,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("failed encoding response: %v", err)
+		}
+	}
+}
+
+// votablePoll loads the poll named in the path and checks that it takes
+// votes. On failure it has already written the response and returns nil.
+func votablePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client) *ent.Poll {
+	pollID, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "invalid poll id", http.StatusBadRequest)
+		return nil
+	}
+	p, err := client.Poll.Get(r.Context(), pollID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			http.Error(w, "invalid poll id", http.StatusBadRequest)
+		} else {
+			log.Printf("error loading poll: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return nil
+	}
+	if !checkVisible(r.Context(), w, client, auth.UserFromContext(r.Context()), p, "invalid poll id", http.StatusBadRequest) {
+		return nil
+	}
+	if verr := lifecycle.CheckVotable(p, time.Now()); verr != nil {
+		writeVoteError(w, verr)
+		return nil
+	}
+	return p
+}
+
+// revisablePoll is votablePoll for changing or retracting a ballot, which
+// the poll must also allow.
+func revisablePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params, client *ent.Client) *ent.Poll {
+	p := votablePoll(w, r, ps, client)
+	if p == nil {
+		return nil
+	}
+	if !p.AllowVoteChanges {
+		http.Error(w, "this poll does not allow changing votes", http.StatusForbidden)
+		return nil
+	}
+	return p
+}
+
+// storeBallot inserts the votes of a ballot on p, in ballot order, and
+// records it in the poll's vote history under action.
+func storeBallot(ctx context.Context, tx *ent.Tx, userID int, p *ent.Poll, optionIDs []int, scores map[int]int, action votehistory.Action) ([]*ent.Vote, error) {
+	if !rated(p.Method) {
+		scores = nil
+	}
+	builders := make([]*ent.VoteCreate, len(optionIDs))
+	for i, optID := range optionIDs {
+		builders[i] = tx.Vote.
+			Create().
+			SetUserID(userID).
+			SetPollID(p.ID).
+			SetOptionID(optID).
+			SetPosition(i)
+		if scores != nil {
+			builders[i].SetScore(scores[optID])
+		}
+	}
+	votes, err := tx.Vote.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordBallot(ctx, tx, userID, p.ID, action, optionIDs, scores); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+// deleteBallots deletes the ballots on a poll whose votes match where and
+// returns their voters. A ballot cast before the vote history was kept is
+// recorded as cast first, so the history still shows what it was.
+func deleteBallots(ctx context.Context, tx *ent.Tx, pollID int, where ...predicate.Vote) ([]int, error) {
+	// 1) Load the ballots, each in ballot order
+	votes, err := tx.Vote.
+		Query().
+		Where(vote.PollIDEQ(pollID)).
+		Where(where...).
+		Order(ent.Asc(vote.FieldUserID), ent.Asc(vote.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) == 0 {
+		return nil, nil
+	}
+	var voters []int
+	ballots := make(map[int][]*ent.Vote)
+	for _, v := range votes {
+		if _, ok := ballots[v.UserID]; !ok {
+			voters = append(voters, v.UserID)
+		}
+		ballots[v.UserID] = append(ballots[v.UserID], v)
+	}
+
+	// 2) Record the ballots the history has never seen, as of when they were cast
+	recorded, err := tx.VoteHistory.
+		Query().
+		Where(votehistory.PollIDEQ(pollID), votehistory.UserIDIn(voters...)).
+		Select(votehistory.FieldUserID).
+		Ints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int]bool, len(recorded))
+	for _, id := range recorded {
+		seen[id] = true
+	}
+	for _, userID := range voters {
+		if seen[userID] {
+			continue
+		}
+		b := ballots[userID]
+		optionIDs := make([]int, len(b))
+		var scores map[int]int
+		for i, v := range b {
+			optionIDs[i] = v.OptionID
+			if v.Score != nil {
+				if scores == nil {
+					scores = make(map[int]int, len(b))
+				}
+				scores[v.OptionID] = *v.Score
+			}
+		}
+		h := tx.VoteHistory.
+			Create().
+			SetUserID(userID).
+			SetPollID(pollID).
+			SetAction(votehistory.ActionCast).
+			SetOptionIds(optionIDs).
+			SetCreatedAt(b[0].CreatedAt)
+		if scores != nil {
+			h.SetScores(scores)
+		}
+		if err := h.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3) Delete the votes
+	if _, err := tx.Vote.
+		Delete().
+		Where(vote.PollIDEQ(pollID)).
+		Where(where...).
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+	return voters, nil
+}
+
+// recordBallot appends an entry to a poll's vote history.
+func recordBallot(ctx context.Context, tx *ent.Tx, userID, pollID int, action votehistory.Action, optionIDs []int, scores map[int]int) error {
+	h := tx.VoteHistory.
+		Create().
+		SetUserID(userID).
+		SetPollID(pollID).
+		SetAction(action).
+		SetOptionIds(optionIDs)
+	if scores != nil {
+		h.SetScores(scores)
+	}
+	return h.Exec(ctx)
+}
+
+// writeBallot answers a cast or changed vote with the ballot as stored and
+// the poll's updated results, redacted if its results policy hides them.
+func writeBallot(ctx context.Context, w http.ResponseWriter, client *ent.Client, p *ent.Poll, status int, votes []*ent.Vote) {
+	// 1) Load updated results, as far as the poll's results policy shows them
+	results, err := visibleResults(ctx, client, auth.UserFromContext(ctx), p)
+	if err != nil {
+		log.Printf("error querying results: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// 2) Build response
+	type ballot struct {
+		OptionIDs []int       `json:"option_ids"`
+		Scores    map[int]int `json:"scores,omitempty"`
+		CreatedAt time.Time   `json:"created_at"`
+	}
+	b := ballot{OptionIDs: make([]int, len(votes))}
+	for i, v := range votes {
+		b.OptionIDs[i] = v.OptionID
+		if v.Score != nil {
+			if b.Scores == nil {
+				b.Scores = make(map[int]int, len(votes))
+			}
+			b.Scores[v.OptionID] = *v.Score
+		}
+	}
+	if len(votes) > 0 {
+		b.CreatedAt = votes[0].CreatedAt
+	}
+	resp := struct {
+		*pollResults
+		Ballot ballot `json:"ballot"`
+	}{
+		pollResults: results,
+		Ballot:      b,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/vote"
+	"pollAppNew/ent/votehistory"
+)
+
+// Deleting a ballot the history has never seen records it as cast first,
+// so its revisions don't start from nothing.
+func TestDeleteBallotsRecordsUnseenBallots(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	alice := client.User.Create().SetUsername("alice").SaveX(ctx)
+	bob := client.User.Create().SetUsername("bob").SaveX(ctx)
+	p := client.Poll.Create().SetTitle("t").SetCreatorID(alice.ID).SetMethod(poll.MethodScore).SaveX(ctx)
+	o1 := client.PollOption.Create().SetPollID(p.ID).SetText("a").SaveX(ctx)
+	o2 := client.PollOption.Create().SetPollID(p.ID).SetText("b").SaveX(ctx)
+
+	// alice's ballot predates the history; bob's was recorded when cast
+	client.Vote.Create().SetUserID(alice.ID).SetPollID(p.ID).SetOptionID(o2.ID).SetPosition(0).SetScore(4).ExecX(ctx)
+	client.Vote.Create().SetUserID(alice.ID).SetPollID(p.ID).SetOptionID(o1.ID).SetPosition(1).SetScore(1).ExecX(ctx)
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storeBallot(ctx, tx, bob.ID, p, []int{o1.ID}, map[int]int{o1.ID: 5}, votehistory.ActionCast); err != nil {
+		t.Fatal(err)
+	}
+
+	voters, err := deleteBallots(ctx, tx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(voters, []int{alice.ID, bob.ID}) {
+		t.Errorf("voters = %v", voters)
+	}
+	if n := client.Vote.Query().Where(vote.PollIDEQ(p.ID)).CountX(ctx); n != 0 {
+		t.Errorf("%d votes left", n)
+	}
+
+	for _, u := range []int{alice.ID, bob.ID} {
+		entries := client.VoteHistory.Query().Where(votehistory.UserIDEQ(u)).AllX(ctx)
+		if len(entries) != 1 || entries[0].Action != votehistory.ActionCast {
+			t.Fatalf("user %d history: %+v", u, entries)
+		}
+	}
+	cast := client.VoteHistory.Query().Where(votehistory.UserIDEQ(alice.ID)).OnlyX(ctx)
+	if !slices.Equal(cast.OptionIds, []int{o2.ID, o1.ID}) || !reflect.DeepEqual(cast.Scores, map[int]int{o2.ID: 4, o1.ID: 1}) {
+		t.Errorf("recorded %v %v, want the ballot as stored", cast.OptionIds, cast.Scores)
+	}
+}
@@ -0,0 +1,277 @@
+// internal/lifecycle/lifecycle.go
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/predicate"
+)
+
+var interval = flag.Duration("lifecycle-interval", time.Minute, "how often scheduled polls are opened and expired polls closed")
+
+// Codes say why a poll isn't taking votes.
+const (
+	CodeDraft     = "poll_draft"
+	CodeScheduled = "poll_not_open_yet"
+	CodeClosed    = "poll_closed"
+	CodeArchived  = "poll_archived"
+)
+
+var (
+	// ErrTransition means the poll's status doesn't allow the change.
+	ErrTransition = errors.New("lifecycle: transition not allowed")
+	// ErrWindow means closes_at doesn't fall after opens_at and now.
+	ErrWindow = errors.New("lifecycle: closes_at must be after opens_at and in the future")
+)
+
+// VoteError explains why a poll isn't taking votes.
+type VoteError struct {
+	Code    string
+	Message string
+}
+
+func (e *VoteError) Error() string {
+	return e.Message
+}
+
+// Status returns p's status at now. The worker applies due transitions
+// only every -lifecycle-interval, so in between this is what counts.
+func Status(p *ent.Poll, now time.Time) poll.Status {
+	due := func(t *time.Time) bool { return t != nil && !now.Before(*t) }
+	switch p.Status {
+	case poll.StatusScheduled:
+		if !due(p.OpensAt) {
+			return poll.StatusScheduled
+		}
+		if due(p.ClosesAt) {
+			return poll.StatusClosed
+		}
+		return poll.StatusOpen
+	case poll.StatusOpen:
+		// closed_at without a closed status predates statuses
+		if due(p.ClosesAt) || p.ClosedAt != nil {
+			return poll.StatusClosed
+		}
+	}
+	return p.Status
+}
+
+// StatusIs matches the polls whose Status at now is s, so that filtering
+// on a status agrees with the one reported before the worker catches up.
+func StatusIs(s poll.Status, now time.Time) predicate.Poll {
+	opened := poll.And(poll.StatusEQ(poll.StatusScheduled), poll.OpensAtLTE(now))
+	running := poll.Or(poll.ClosesAtIsNil(), poll.ClosesAtGT(now))
+	switch s {
+	case poll.StatusScheduled:
+		return poll.And(poll.StatusEQ(poll.StatusScheduled), poll.Or(poll.OpensAtIsNil(), poll.OpensAtGT(now)))
+	case poll.StatusOpen:
+		return poll.Or(
+			poll.And(opened, running),
+			poll.And(poll.StatusEQ(poll.StatusOpen), running, poll.ClosedAtIsNil()),
+		)
+	case poll.StatusClosed:
+		return poll.Or(
+			poll.StatusEQ(poll.StatusClosed),
+			poll.And(opened, poll.ClosesAtLTE(now)),
+			poll.And(poll.StatusEQ(poll.StatusOpen), poll.Or(poll.ClosesAtLTE(now), poll.ClosedAtNotNil())),
+		)
+	default:
+		return poll.StatusEQ(s)
+	}
+}
+
+// CheckVotable says why p takes no votes at now, or returns nil if it does.
+func CheckVotable(p *ent.Poll, now time.Time) *VoteError {
+	switch Status(p, now) {
+	case poll.StatusOpen:
+		return nil
+	case poll.StatusDraft:
+		return &VoteError{CodeDraft, "poll has not been published"}
+	case poll.StatusScheduled:
+		return &VoteError{CodeScheduled, fmt.Sprintf("poll opens at %s", p.OpensAt.UTC().Format(time.RFC3339))}
+	case poll.StatusArchived:
+		return &VoteError{CodeArchived, "poll is archived"}
+	default:
+		return &VoteError{CodeClosed, "poll is closed"}
+	}
+}
+
+// Initial returns the status of a new poll: a draft if asked for, else
+// scheduled or open depending on opensAt.
+func Initial(draft bool, opensAt, closesAt *time.Time, now time.Time) (poll.Status, error) {
+	if err := checkWindow(opensAt, closesAt, now); err != nil {
+		return "", err
+	}
+	switch {
+	case draft:
+		return poll.StatusDraft, nil
+	case opensAt != nil && opensAt.After(now):
+		return poll.StatusScheduled, nil
+	default:
+		return poll.StatusOpen, nil
+	}
+}
+
+func checkWindow(opensAt, closesAt *time.Time, now time.Time) error {
+	if closesAt == nil {
+		return nil
+	}
+	if !closesAt.After(now) || (opensAt != nil && !closesAt.After(*opensAt)) {
+		return ErrWindow
+	}
+	return nil
+}
+
+// Publish moves a draft to scheduled or open. A nil opensAt or closesAt
+// keeps the one the draft was created with.
+func Publish(ctx context.Context, client *ent.Client, p *ent.Poll, opensAt, closesAt *time.Time, now time.Time) (*ent.Poll, error) {
+	if p.Status != poll.StatusDraft {
+		return nil, ErrTransition
+	}
+	if opensAt == nil {
+		opensAt = p.OpensAt
+	}
+	if closesAt == nil {
+		closesAt = p.ClosesAt
+	}
+	status, err := Initial(false, opensAt, closesAt, now)
+	if err != nil {
+		return nil, err
+	}
+	if opensAt == nil {
+		opensAt = &now
+	}
+	return update(ctx, client, p, func(u *ent.PollUpdate) {
+		u.SetStatus(status).
+			SetOpensAt(*opensAt).
+			SetNillableClosesAt(closesAt)
+	})
+}
+
+// Close stops a scheduled or open poll from taking votes before its
+// closes_at.
+func Close(ctx context.Context, client *ent.Client, p *ent.Poll, now time.Time) (*ent.Poll, error) {
+	if s := Status(p, now); s != poll.StatusOpen && s != poll.StatusScheduled {
+		return nil, ErrTransition
+	}
+	return update(ctx, client, p, func(u *ent.PollUpdate) {
+		u.SetStatus(poll.StatusClosed).
+			SetClosedAt(now)
+	})
+}
+
+// Reopen opens a closed poll again, until closesAt or indefinitely when
+// it is nil.
+func Reopen(ctx context.Context, client *ent.Client, p *ent.Poll, closesAt *time.Time, now time.Time) (*ent.Poll, error) {
+	if Status(p, now) != poll.StatusClosed {
+		return nil, ErrTransition
+	}
+	if err := checkWindow(nil, closesAt, now); err != nil {
+		return nil, err
+	}
+	return update(ctx, client, p, func(u *ent.PollUpdate) {
+		u.SetStatus(poll.StatusOpen).
+			ClearClosedAt()
+		if closesAt != nil {
+			u.SetClosesAt(*closesAt)
+		} else {
+			u.ClearClosesAt()
+		}
+		if p.OpensAt == nil || p.OpensAt.After(now) {
+			u.SetOpensAt(now)
+		}
+	})
+}
+
+// Archive retires a closed poll.
+func Archive(ctx context.Context, client *ent.Client, p *ent.Poll, now time.Time) (*ent.Poll, error) {
+	if Status(p, now) != poll.StatusClosed {
+		return nil, ErrTransition
+	}
+	return update(ctx, client, p, func(u *ent.PollUpdate) {
+		u.SetStatus(poll.StatusArchived)
+		if p.ClosedAt == nil {
+			u.SetClosedAt(now)
+		}
+	})
+}
+
+// update applies set to p only if its stored status hasn't changed since
+// p was loaded, so concurrent transitions can't both win.
+func update(ctx context.Context, client *ent.Client, p *ent.Poll, set func(*ent.PollUpdate)) (*ent.Poll, error) {
+	u := client.Poll.
+		Update().
+		Where(poll.IDEQ(p.ID), poll.StatusEQ(p.Status))
+	set(u)
+	n, err := u.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrTransition
+	}
+	return client.Poll.Get(ctx, p.ID)
+}
+
+// Tick stores the transitions due at now: scheduled polls past opens_at
+// open, and open polls past closes_at close.
+func Tick(ctx context.Context, client *ent.Client, now time.Time) error {
+	if _, err := client.Poll.
+		Update().
+		Where(poll.StatusEQ(poll.StatusScheduled), poll.OpensAtLTE(now)).
+		SetStatus(poll.StatusOpen).
+		Save(ctx); err != nil {
+		return fmt.Errorf("opening scheduled polls: %w", err)
+	}
+	// polls closed before statuses existed keep their closed_at
+	if _, err := client.Poll.
+		Update().
+		Where(poll.StatusEQ(poll.StatusOpen), poll.ClosedAtNotNil()).
+		SetStatus(poll.StatusClosed).
+		Save(ctx); err != nil {
+		return fmt.Errorf("closing polls: %w", err)
+	}
+	// expired polls closed at closes_at, however late the tick runs
+	expired, err := client.Poll.
+		Query().
+		Where(poll.StatusEQ(poll.StatusOpen), poll.ClosesAtLTE(now)).
+		Select(poll.FieldID, poll.FieldClosesAt).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("finding expired polls: %w", err)
+	}
+	for _, p := range expired {
+		if _, err := client.Poll.
+			Update().
+			Where(poll.IDEQ(p.ID), poll.StatusEQ(poll.StatusOpen)).
+			SetStatus(poll.StatusClosed).
+			SetClosedAt(*p.ClosesAt).
+			Save(ctx); err != nil {
+			return fmt.Errorf("closing expired poll %d: %w", p.ID, err)
+		}
+	}
+	return nil
+}
+
+// Run calls Tick on the -lifecycle-interval until ctx is done.
+func Run(ctx context.Context, client *ent.Client) {
+	t := time.NewTicker(*interval)
+	defer t.Stop()
+	for {
+		if err := Tick(ctx, client, time.Now()); err != nil {
+			log.Printf("failed updating poll statuses: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
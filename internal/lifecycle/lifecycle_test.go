@@ -0,0 +1,81 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pollAppNew/ent/enttest"
+	"pollAppNew/ent/poll"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// StatusIs must match exactly the polls Status reports as s, including
+// those whose transition the worker hasn't stored yet.
+func TestStatusIsAgreesWithStatus(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:lifecycle?mode=memory&_fk=1")
+	defer client.Close()
+	u := client.User.Create().SetUsername("alice").SaveX(ctx)
+
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	stored := []poll.Status{poll.StatusDraft, poll.StatusScheduled, poll.StatusOpen, poll.StatusClosed, poll.StatusArchived}
+	times := []*time.Time{nil, &past, &now, &future}
+	for _, st := range stored {
+		for _, opens := range times {
+			for _, closes := range times {
+				for _, closed := range []*time.Time{nil, &past} {
+					client.Poll.Create().
+						SetTitle("t").
+						SetCreatorID(u.ID).
+						SetStatus(st).
+						SetNillableOpensAt(opens).
+						SetNillableClosesAt(closes).
+						SetNillableClosedAt(closed).
+						ExecX(ctx)
+				}
+			}
+		}
+	}
+
+	polls := client.Poll.Query().AllX(ctx)
+	for _, s := range stored {
+		ids := client.Poll.Query().Where(StatusIs(s, now)).IDsX(ctx)
+		matched := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			matched[id] = true
+		}
+		for _, p := range polls {
+			if want := Status(p, now) == s; matched[p.ID] != want {
+				t.Errorf("StatusIs(%s) = %v for stored %s, opens_at %v, closes_at %v, closed_at %v; Status is %s",
+					s, matched[p.ID], p.Status, p.OpensAt, p.ClosesAt, p.ClosedAt, Status(p, now))
+			}
+		}
+	}
+}
+
+// Tick closes an expired poll at its closes_at, not whenever it ran.
+func TestTickClosesAtClosesAt(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:tick?mode=memory&_fk=1")
+	defer client.Close()
+	u := client.User.Create().SetUsername("alice").SaveX(ctx)
+
+	now := time.Now()
+	closes := now.Add(-time.Hour).Truncate(time.Second)
+	expired := client.Poll.Create().SetTitle("t").SetCreatorID(u.ID).SetStatus(poll.StatusOpen).SetClosesAt(closes).SaveX(ctx)
+	running := client.Poll.Create().SetTitle("t").SetCreatorID(u.ID).SetStatus(poll.StatusOpen).SetClosesAt(now.Add(time.Hour)).SaveX(ctx)
+
+	if err := Tick(ctx, client, now); err != nil {
+		t.Fatal(err)
+	}
+	p := client.Poll.GetX(ctx, expired.ID)
+	if p.Status != poll.StatusClosed || p.ClosedAt == nil || !p.ClosedAt.Equal(closes) {
+		t.Errorf("expired poll: status %s, closed_at %v, want closed at %v", p.Status, p.ClosedAt, closes)
+	}
+	if p := client.Poll.GetX(ctx, running.ID); p.Status != poll.StatusOpen || p.ClosedAt != nil {
+		t.Errorf("running poll: status %s, closed_at %v", p.Status, p.ClosedAt)
+	}
+}
@@ -0,0 +1,69 @@
+// internal/mail/mail.go
+package mail
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+)
+
+var (
+	publicURL = flag.String("public-url", "http://localhost:8080", "base URL of the web front end; emailed links point at its /verify-email and /reset-password pages")
+	smtpAddr  = flag.String("smtp-addr", "", "SMTP server host:port; when empty, mail is written to -mail-outbox instead")
+	smtpUser  = flag.String("smtp-user", "", "SMTP username (PLAIN auth)")
+	smtpPass  = flag.String("smtp-password", "", "SMTP password (PLAIN auth)")
+	mailFrom  = flag.String("mail-from", "pollapp@localhost", "sender address of outgoing mail")
+	outboxDir = flag.String("mail-outbox", "outbox", "directory outgoing mail is written to when no SMTP server is configured")
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages. Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// FromFlags returns an SMTP mailer when -smtp-addr is set and a file outbox otherwise.
+func FromFlags() (Mailer, error) {
+	if *smtpAddr != "" {
+		return &SMTP{
+			Addr:     *smtpAddr,
+			Username: *smtpUser,
+			Password: *smtpPass,
+			From:     *mailFrom,
+		}, nil
+	}
+	return NewOutbox(*outboxDir, *mailFrom)
+}
+
+// Link returns an absolute URL under -public-url carrying token as ?token=.
+func Link(path, token string) string {
+	return strings.TrimRight(*publicURL, "/") + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// format renders m as an RFC 5322 message.
+func format(from string, m Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// ValidAddress reports whether s is a bare email address such as "a@b.example".
+func ValidAddress(s string) bool {
+	addr, err := netmail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
@@ -0,0 +1,33 @@
+// internal/mail/outbox.go
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// Outbox writes each message to its own .eml file instead of sending it,
+// for development and offline tests.
+type Outbox struct {
+	Dir  string
+	From string
+	seq  atomic.Int64
+}
+
+// NewOutbox creates dir if needed and returns an Outbox writing into it.
+func NewOutbox(dir, from string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Outbox{Dir: dir, From: from}, nil
+}
+
+// Send implements Mailer.
+func (o *Outbox) Send(_ context.Context, m Message) error {
+	name := fmt.Sprintf("%s-%04d.eml", time.Now().UTC().Format("20060102T150405.000000000"), o.seq.Add(1))
+	return os.WriteFile(filepath.Join(o.Dir, name), format(o.From, m), 0o600)
+}
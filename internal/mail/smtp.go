@@ -0,0 +1,29 @@
+// internal/mail/smtp.go
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+)
+
+// SMTP sends mail through an SMTP relay, upgrading to TLS when offered.
+type SMTP struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send implements Mailer.
+func (s *SMTP) Send(_ context.Context, m Message) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	return smtp.SendMail(s.Addr, auth, s.From, []string{m.To}, format(s.From, m))
+}
@@ -0,0 +1,76 @@
+// internal/mailtoken/mailtoken.go
+package mailtoken
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/usertoken"
+	"pollAppNew/internal/session"
+)
+
+// ErrInvalid is returned for unknown, expired or already used tokens.
+var ErrInvalid = errors.New("invalid or expired token")
+
+// Issue creates a token for userID and returns its plaintext value. Earlier
+// unused tokens of the same purpose are discarded, so only the newest link works.
+func Issue(ctx context.Context, client *ent.Client, userID int, purpose usertoken.Purpose, email string, ttl time.Duration) (string, error) {
+	raw, hash, err := session.NewToken()
+	if err != nil {
+		return "", err
+	}
+	if _, err := client.UserToken.
+		Delete().
+		Where(
+			usertoken.UserIDEQ(userID),
+			usertoken.PurposeEQ(purpose),
+			usertoken.UsedAtIsNil(),
+		).
+		Exec(ctx); err != nil {
+		return "", err
+	}
+	if err := client.UserToken.
+		Create().
+		SetPurpose(purpose).
+		SetTokenHash(hash).
+		SetUserID(userID).
+		SetEmail(email).
+		SetExpiresAt(time.Now().Add(ttl)).
+		Exec(ctx); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// Consume marks the token as used and returns it, with its user loaded. The
+// conditional update makes concurrent redemptions of one token fail.
+func Consume(ctx context.Context, client *ent.Client, raw string, purpose usertoken.Purpose) (*ent.UserToken, error) {
+	if raw == "" {
+		return nil, ErrInvalid
+	}
+	now := time.Now()
+	hash := session.HashToken(raw)
+	n, err := client.UserToken.
+		Update().
+		Where(
+			usertoken.TokenHashEQ(hash),
+			usertoken.PurposeEQ(purpose),
+			usertoken.UsedAtIsNil(),
+			usertoken.ExpiresAtGT(now),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrInvalid
+	}
+	return client.UserToken.
+		Query().
+		Where(usertoken.TokenHashEQ(hash)).
+		WithUser().
+		Only(ctx)
+}
@@ -0,0 +1,285 @@
+// internal/mfa/mfa.go
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/poll"
+	"pollAppNew/ent/recoverycode"
+	"pollAppNew/ent/user"
+	"pollAppNew/internal/session"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// recoveryCodeCount is how many recovery codes an enrollment hands out.
+const recoveryCodeCount = 10
+
+var (
+	issuer  = flag.String("totp-issuer", "PollApp", "issuer name shown by authenticator apps")
+	require = flag.String("require-2fa", "", `comma-separated account classes that must enroll in two-factor authentication before using authenticated routes: "owners" (users who own a poll), "moderators" (moderators and admins) or "admins"`)
+)
+
+// policyClasses are the account classes -require-2fa understands.
+var policyClasses = map[string]bool{"owners": true, "moderators": true, "admins": true}
+
+var (
+	// ErrInvalidCode is returned for a wrong, expired or replayed code.
+	ErrInvalidCode = errors.New("invalid two-factor code")
+	// ErrAlreadyEnabled is returned when enrolling an account that already uses 2FA.
+	ErrAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	// ErrNotEnrolled is returned when an operation needs an enrollment that doesn't exist.
+	ErrNotEnrolled = errors.New("two-factor authentication not enrolled")
+	// ErrRequired is returned when policy forbids turning 2FA off.
+	ErrRequired = errors.New("two-factor authentication is required for this account")
+)
+
+// CheckPolicy validates -require-2fa.
+func CheckPolicy() error {
+	for _, c := range requiredClasses() {
+		if !policyClasses[c] {
+			return fmt.Errorf("-require-2fa: unknown account class %q", c)
+		}
+	}
+	return nil
+}
+
+// requiredClasses splits -require-2fa.
+func requiredClasses() []string {
+	var out []string
+	for _, c := range strings.Split(*require, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Required reports whether policy obliges u to use two-factor authentication.
+func Required(ctx context.Context, client *ent.Client, u *ent.User) (bool, error) {
+	for _, c := range requiredClasses() {
+		switch c {
+		case "admins":
+			if u.Role == user.RoleAdmin {
+				return true, nil
+			}
+		case "moderators":
+			if u.Role == user.RoleModerator || u.Role == user.RoleAdmin {
+				return true, nil
+			}
+		case "owners":
+			owns, err := client.Poll.
+				Query().
+				Where(poll.CreatorIDEQ(u.ID)).
+				Exist(ctx)
+			if err != nil || owns {
+				return owns, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// Enroll generates a fresh secret for u and returns it with its provisioning
+// URI. The secret isn't trusted until Confirm sees a code from it.
+func Enroll(ctx context.Context, client *ent.Client, u *ent.User) (secret, uri string, err error) {
+	if u.TotpEnabled {
+		return "", "", ErrAlreadyEnabled
+	}
+	secret, err = newSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := client.User.
+		UpdateOneID(u.ID).
+		SetTotpSecret(secret).
+		Exec(ctx); err != nil {
+		return "", "", err
+	}
+	return secret, provisioningURI(*issuer, u.Username, secret), nil
+}
+
+// QRCode renders the provisioning URI of u's pending enrollment as a PNG.
+func QRCode(u *ent.User) ([]byte, error) {
+	if u.TotpEnabled {
+		return nil, ErrAlreadyEnabled
+	}
+	if u.TotpSecret == "" {
+		return nil, ErrNotEnrolled
+	}
+	return qrcode.Encode(provisioningURI(*issuer, u.Username, u.TotpSecret), qrcode.Medium, 256)
+}
+
+// Confirm turns on 2FA for u once code proves the authenticator app holds
+// the pending secret, and returns a first set of recovery codes.
+func Confirm(ctx context.Context, client *ent.Client, u *ent.User, code string) ([]string, error) {
+	if u.TotpEnabled {
+		return nil, ErrAlreadyEnabled
+	}
+	if u.TotpSecret == "" {
+		return nil, ErrNotEnrolled
+	}
+	s, ok := validate(u.TotpSecret, normalize(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+	return withTx(ctx, client, func(tx *ent.Client) ([]string, error) {
+		if err := tx.User.
+			UpdateOneID(u.ID).
+			SetTotpEnabled(true).
+			SetTotpLastStep(s).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		return newRecoveryCodes(ctx, tx, u.ID)
+	})
+}
+
+// Verify checks a second factor for u: a current TOTP code, or one of its
+// unused recovery codes, which is then burnt. A TOTP code is accepted at
+// most once.
+func Verify(ctx context.Context, client *ent.Client, u *ent.User, code string) error {
+	if !u.TotpEnabled {
+		return ErrNotEnrolled
+	}
+	code = normalize(code)
+	if len(code) == digits {
+		s, ok := validate(u.TotpSecret, code, time.Now())
+		if !ok {
+			return ErrInvalidCode
+		}
+		// only move forward, so a concurrent or repeated use of the same code fails
+		n, err := client.User.
+			Update().
+			Where(user.IDEQ(u.ID), user.TotpLastStepLT(s)).
+			SetTotpLastStep(s).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrInvalidCode
+		}
+		return nil
+	}
+	n, err := client.RecoveryCode.
+		Update().
+		Where(
+			recoverycode.UserIDEQ(u.ID),
+			recoverycode.CodeHashEQ(session.HashToken(code)),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all of u's recovery codes after checking code.
+func RegenerateRecoveryCodes(ctx context.Context, client *ent.Client, u *ent.User, code string) ([]string, error) {
+	if err := Verify(ctx, client, u, code); err != nil {
+		return nil, err
+	}
+	return withTx(ctx, client, func(tx *ent.Client) ([]string, error) {
+		return newRecoveryCodes(ctx, tx, u.ID)
+	})
+}
+
+// RemainingRecoveryCodes counts u's unused recovery codes.
+func RemainingRecoveryCodes(ctx context.Context, client *ent.Client, userID int) (int, error) {
+	return client.RecoveryCode.
+		Query().
+		Where(recoverycode.UserIDEQ(userID), recoverycode.UsedAtIsNil()).
+		Count(ctx)
+}
+
+// Disable turns 2FA off for u after checking code, unless policy requires it.
+func Disable(ctx context.Context, client *ent.Client, u *ent.User, code string) error {
+	required, err := Required(ctx, client, u)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrRequired
+	}
+	if err := Verify(ctx, client, u, code); err != nil {
+		return err
+	}
+	_, err = withTx(ctx, client, func(tx *ent.Client) ([]string, error) {
+		if _, err := tx.RecoveryCode.
+			Delete().
+			Where(recoverycode.UserIDEQ(u.ID)).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		return nil, tx.User.
+			UpdateOneID(u.ID).
+			ClearTotpSecret().
+			SetTotpEnabled(false).
+			SetTotpLastStep(0).
+			Exec(ctx)
+	})
+	return err
+}
+
+// newRecoveryCodes replaces userID's recovery codes and returns the new ones
+// in the "xxxxx-xxxxx" form shown to the user.
+func newRecoveryCodes(ctx context.Context, client *ent.Client, userID int) ([]string, error) {
+	if _, err := client.RecoveryCode.
+		Delete().
+		Where(recoverycode.UserIDEQ(userID)).
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+	codes := make([]string, recoveryCodeCount)
+	creates := make([]*ent.RecoveryCodeCreate, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(b32.EncodeToString(b))[:10]
+		codes[i] = raw[:5] + "-" + raw[5:]
+		creates[i] = client.RecoveryCode.
+			Create().
+			SetUserID(userID).
+			SetCodeHash(session.HashToken(raw))
+	}
+	if _, err := client.RecoveryCode.CreateBulk(creates...).Save(ctx); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalize strips the spaces and dashes people type into codes.
+func normalize(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// withTx runs fn in a transaction on client.
+func withTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Client) ([]string, error)) ([]string, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := fn(tx.Client())
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return nil, err
+	}
+	return out, tx.Commit()
+}
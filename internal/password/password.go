@@ -0,0 +1,74 @@
+// internal/password/password.go
+package password
+
+import (
+	"crypto/subtle"
+	"flag"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// Raising -bcrypt-cost later is safe: existing hashes are upgraded on the next successful login.
+	cost = flag.Int("bcrypt-cost", bcrypt.DefaultCost, "bcrypt cost factor used when hashing passwords")
+)
+
+// ErrTooLong is returned by Hash for passwords bcrypt cannot represent (more than 72 bytes).
+var ErrTooLong = bcrypt.ErrPasswordTooLong
+
+// dummyHash is compared against when no stored hash exists, so that unknown
+// usernames take as long to reject as wrong passwords. It is made on first
+// use, after flags are parsed, so it has the configured cost.
+var dummyHash = sync.OnceValue(func() []byte {
+	h, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), *cost)
+	return h
+})
+
+// Hash returns a bcrypt hash of plain using the configured cost.
+func Hash(plain string) (string, error) {
+	h, err := bcrypt.GenerateFromPassword([]byte(plain), *cost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// Verify reports whether plain matches the stored value. stored is either a
+// bcrypt hash or a legacy plaintext password written before hashing existed.
+// rehash is true when the match succeeded but stored should be replaced with
+// a fresh Hash(plain): the row is still plaintext or uses an outdated cost.
+func Verify(stored, plain string) (ok, rehash bool) {
+	if stored == "" {
+		// no password set (SSO-only account): nothing can match
+		VerifyDummy(plain)
+		return false, false
+	}
+	if !isBcrypt(stored) {
+		// legacy plaintext row: compare in constant time, then upgrade it
+		ok = subtle.ConstantTimeCompare([]byte(stored), []byte(plain)) == 1
+		return ok, ok
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(plain)); err != nil {
+		return false, false
+	}
+	c, err := bcrypt.Cost([]byte(stored))
+	return true, err != nil || c != *cost
+}
+
+// VerifyDummy burns the same amount of time as a failed Verify against a real
+// hash. Call it when the user does not exist to avoid leaking that fact.
+func VerifyDummy(plain string) {
+	bcrypt.CompareHashAndPassword(dummyHash(), []byte(plain))
+}
+
+func isBcrypt(stored string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(stored, prefix) {
+			_, err := bcrypt.Cost([]byte(stored))
+			return err == nil
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// internal/ratelimit/memory.go
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops expired entries.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps entries in process memory. It suits a single node;
+// several nodes need a shared Store so their counters add up.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]Entry
+	nextSweep time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]Entry{}}
+}
+
+// Get returns the unexpired entry for key.
+func (m *MemoryStore) Get(_ context.Context, key string) (Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(key, time.Now()), nil
+}
+
+// Update applies fn to the entry for key under the store's lock.
+func (m *MemoryStore) Update(_ context.Context, key string, fn func(e *Entry)) (Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.sweep(now)
+	e := m.get(key, now)
+	fn(&e)
+	m.entries[key] = e
+	return e, nil
+}
+
+// Delete forgets key.
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *MemoryStore) get(key string, now time.Time) Entry {
+	e, ok := m.entries[key]
+	if !ok || now.After(e.Expires) {
+		return Entry{}
+	}
+	return e
+}
+
+// sweep drops expired entries at most once per sweepInterval, so memory
+// stays bounded by the keys seen within their windows.
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Before(m.nextSweep) {
+		return
+	}
+	m.nextSweep = now.Add(sweepInterval)
+	for k, e := range m.entries {
+		if now.After(e.Expires) {
+			delete(m.entries, k)
+		}
+	}
+}
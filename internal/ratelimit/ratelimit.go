@@ -0,0 +1,177 @@
+// internal/ratelimit/ratelimit.go
+package ratelimit
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+)
+
+var (
+	lockoutThreshold = flag.Int("login-lockout-threshold", 10, "failed logins after which an account is locked; 0 disables lockout")
+	lockoutDuration  = flag.Duration("login-lockout-duration", 15*time.Minute, "how long a locked account stays locked")
+	ipThreshold      = flag.Int("login-ip-threshold", 20, "failed logins from one IP before it has to back off")
+	signupLimit      = flag.Int("signup-ip-limit", 5, "accounts one IP may create per hour before it has to back off")
+)
+
+// Entry is the state kept per key.
+type Entry struct {
+	Hits        int
+	Last        time.Time
+	LockedUntil time.Time
+	// Expires is when the entry may be forgotten.
+	Expires time.Time
+}
+
+// Store keeps entries. Implementations must be safe for concurrent use, and
+// Update must apply fn atomically so concurrent hits aren't lost.
+type Store interface {
+	// Get returns the entry for key, or the zero Entry if there is none or it expired.
+	Get(ctx context.Context, key string) (Entry, error)
+	// Update applies fn to the entry for key and stores the result.
+	Update(ctx context.Context, key string, fn func(e *Entry)) (Entry, error)
+	// Delete forgets key.
+	Delete(ctx context.Context, key string) error
+}
+
+// Policy says how quickly a key is throttled.
+type Policy struct {
+	// Free is how many hits are allowed before backoff starts.
+	Free int
+	// Base is the first backoff delay; every further hit doubles it, up to Max.
+	Base, Max time.Duration
+	// Window is how long a key is remembered after its last hit.
+	Window time.Duration
+	// LockAfter locks the key for LockFor once it has that many hits; 0 never locks.
+	LockAfter int
+	LockFor   time.Duration
+}
+
+// LimitedError reports that a key must wait before trying again.
+type LimitedError struct {
+	RetryAfter time.Duration
+	// Locked is set when the key is locked rather than merely backing off.
+	Locked bool
+}
+
+func (e *LimitedError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("locked, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+// Limiter applies a Policy to the keys in a Store.
+type Limiter struct {
+	store  Store
+	policy Policy
+	// prefix namespaces this limiter's keys in a shared store.
+	prefix string
+}
+
+// New returns a limiter over store whose keys are namespaced by prefix.
+func New(store Store, prefix string, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy, prefix: prefix + ":"}
+}
+
+// Check returns a *LimitedError if key is locked or still backing off.
+func (l *Limiter) Check(ctx context.Context, key string) error {
+	e, err := l.store.Get(ctx, l.prefix+key)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Before(e.LockedUntil) {
+		return &LimitedError{RetryAfter: e.LockedUntil.Sub(now), Locked: true}
+	}
+	if d := l.delay(e.Hits); d > 0 {
+		if until := e.Last.Add(d); now.Before(until) {
+			return &LimitedError{RetryAfter: until.Sub(now)}
+		}
+	}
+	return nil
+}
+
+// Hit counts one attempt against key, locking it once the policy says so.
+func (l *Limiter) Hit(ctx context.Context, key string) error {
+	now := time.Now()
+	_, err := l.store.Update(ctx, l.prefix+key, func(e *Entry) {
+		e.Hits++
+		e.Last = now
+		if l.policy.LockAfter > 0 && e.Hits >= l.policy.LockAfter {
+			// after the lock the key starts over with its free attempts
+			e.Hits = 0
+			e.LockedUntil = now.Add(l.policy.LockFor)
+		}
+		e.Expires = now.Add(l.policy.Window)
+		if e.LockedUntil.After(e.Expires) {
+			e.Expires = e.LockedUntil
+		}
+	})
+	return err
+}
+
+// Reset clears key, e.g. after a successful login or an admin unlock.
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	return l.store.Delete(ctx, l.prefix+key)
+}
+
+// delay is the backoff owed after hits attempts.
+func (l *Limiter) delay(hits int) time.Duration {
+	if hits < l.policy.Free || l.policy.Base <= 0 {
+		return 0
+	}
+	d := l.policy.Base
+	for i := l.policy.Free; i < hits && d < l.policy.Max; i++ {
+		d *= 2
+	}
+	return min(d, l.policy.Max)
+}
+
+// Limits groups the throttles in front of the authentication endpoints.
+type Limits struct {
+	// Account counts failed logins per username and locks the account.
+	Account *Limiter
+	// IP counts failed logins per client IP.
+	IP *Limiter
+	// Signup counts accounts created per client IP.
+	Signup *Limiter
+	// Reauth counts wrong passwords and 2FA codes per user on routes that
+	// re-check a signed-in caller, so a stolen session can't guess them,
+	// and wrong codes redeeming a login's 2FA challenge, so a known
+	// password can't start challenge after challenge to guess the code.
+	Reauth *Limiter
+}
+
+// FromFlags builds the authentication limits over store.
+func FromFlags(store Store) *Limits {
+	return &Limits{
+		Account: New(store, "login-user", Policy{
+			Free:      3,
+			Base:      time.Second,
+			Max:       5 * time.Minute,
+			Window:    15 * time.Minute,
+			LockAfter: *lockoutThreshold,
+			LockFor:   *lockoutDuration,
+		}),
+		IP: New(store, "login-ip", Policy{
+			Free:   *ipThreshold,
+			Base:   time.Second,
+			Max:    15 * time.Minute,
+			Window: time.Hour,
+		}),
+		Signup: New(store, "signup-ip", Policy{
+			Free:   *signupLimit,
+			Base:   time.Minute,
+			Max:    time.Hour,
+			Window: time.Hour,
+		}),
+		Reauth: New(store, "reauth-user", Policy{
+			Free:   3,
+			Base:   time.Second,
+			Max:    15 * time.Minute,
+			Window: time.Hour,
+		}),
+	}
+}
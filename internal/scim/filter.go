@@ -0,0 +1,472 @@
+// internal/scim/filter.go
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Filter is a parsed SCIM filter expression (RFC 7644 section 3.4.2.2).
+type Filter interface {
+	filter()
+}
+
+// Compare is "attr op value", or "attr pr" with a nil Value.
+type Compare struct {
+	// Attr is the lower-cased attribute path, e.g. "username" or "emails.value".
+	Attr  string
+	Op    string
+	Value any
+}
+
+// And matches when both sides match.
+type And struct{ L, R Filter }
+
+// Or matches when either side matches.
+type Or struct{ L, R Filter }
+
+// Not inverts X.
+type Not struct{ X Filter }
+
+func (Compare) filter() {}
+func (And) filter()     {}
+func (Or) filter()      {}
+func (Not) filter()     {}
+
+var operators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true, "pr": true,
+}
+
+// ParseFilter parses a filter such as
+//
+//	userName eq "jane" and (emails[type eq "work"] pr or not (active eq false))
+//
+// Operators and attribute names are case-insensitive; attribute names may
+// carry their schema URN.
+func ParseFilter(s string) (Filter, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	f, err := p.or("")
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != "" {
+		return nil, fmt.Errorf("unexpected %q", t)
+	}
+	return f, nil
+}
+
+// lex splits s into parentheses, brackets, JSON strings and bare words.
+func lex(s string) ([]string, error) {
+	var toks []string
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			toks = append(toks, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			toks = append(toks, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\r\n()[]\"", rune(s[j])) {
+				j++
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		}
+	}
+	return toks, nil
+}
+
+type parser struct {
+	toks []string
+	pos  int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(tok string) error {
+	if t := p.next(); t != tok {
+		return fmt.Errorf("expected %q, got %q", tok, t)
+	}
+	return nil
+}
+
+// or parses the lowest-precedence level. prefix is the parent attribute
+// inside a value path such as emails[...].
+func (p *parser) or(prefix string) (Filter, error) {
+	l, err := p.and(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		r, err := p.and(prefix)
+		if err != nil {
+			return nil, err
+		}
+		l = Or{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) and(prefix string) (Filter, error) {
+	l, err := p.unary(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		r, err := p.unary(prefix)
+		if err != nil {
+			return nil, err
+		}
+		l = And{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) unary(prefix string) (Filter, error) {
+	switch t := p.peek(); {
+	case strings.EqualFold(t, "not"):
+		p.next()
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		x, err := p.or(prefix)
+		if err != nil {
+			return nil, err
+		}
+		return Not{x}, p.expect(")")
+	case t == "(":
+		p.next()
+		x, err := p.or(prefix)
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case t == "":
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	return p.comparison(prefix)
+}
+
+func (p *parser) comparison(prefix string) (Filter, error) {
+	attr := AttrName(p.next())
+	if !validAttr(attr) {
+		return nil, fmt.Errorf("invalid attribute %q", attr)
+	}
+	if prefix != "" {
+		attr = prefix + "." + attr
+	}
+
+	// attr[filter] applies filter to the sub-attributes of a multi-valued attribute
+	if p.peek() == "[" {
+		if prefix != "" {
+			return nil, fmt.Errorf("nested value paths are not supported")
+		}
+		p.next()
+		x, err := p.or(attr)
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect("]")
+	}
+
+	op := strings.ToLower(p.next())
+	if !operators[op] {
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+	if op == "pr" {
+		return Compare{Attr: attr, Op: op}, nil
+	}
+	var v any
+	if err := json.Unmarshal([]byte(p.next()), &v); err != nil {
+		return nil, fmt.Errorf("invalid value for %s", attr)
+	}
+	if _, ok := v.([]any); ok {
+		return nil, fmt.Errorf("invalid value for %s", attr)
+	}
+	if _, ok := v.(map[string]any); ok {
+		return nil, fmt.Errorf("invalid value for %s", attr)
+	}
+	return Compare{Attr: attr, Op: op, Value: v}, nil
+}
+
+// AttrName lower-cases an attribute path and strips a schema URN prefix,
+// so "urn:ietf:params:scim:schemas:core:2.0:User:userName" is "username".
+func AttrName(s string) string {
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		s = s[strings.LastIndex(s, ":")+1:]
+	}
+	return strings.ToLower(s)
+}
+
+func validAttr(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '_' && r != '-' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
+
+// Attr compiles one comparison on an attribute into a SQL predicate.
+type Attr func(op string, value any) (func(*sql.Selector), error)
+
+// Compile turns f into a SQL predicate using attrs, keyed by lower-cased
+// attribute path. Unknown attributes are an error.
+func Compile(f Filter, attrs map[string]Attr) (func(*sql.Selector), error) {
+	switch f := f.(type) {
+	case Compare:
+		a, ok := attrs[f.Attr]
+		if !ok {
+			return nil, fmt.Errorf("filtering on %q is not supported", f.Attr)
+		}
+		return a(f.Op, f.Value)
+	case And:
+		l, r, err := compileBoth(f.L, f.R, attrs)
+		if err != nil {
+			return nil, err
+		}
+		return sql.AndPredicates(l, r), nil
+	case Or:
+		l, r, err := compileBoth(f.L, f.R, attrs)
+		if err != nil {
+			return nil, err
+		}
+		return sql.OrPredicates(l, r), nil
+	case Not:
+		x, err := Compile(f.X, attrs)
+		if err != nil {
+			return nil, err
+		}
+		return sql.NotPredicates(x), nil
+	}
+	return nil, fmt.Errorf("unsupported filter %T", f)
+}
+
+func compileBoth(l, r Filter, attrs map[string]Attr) (func(*sql.Selector), func(*sql.Selector), error) {
+	lp, err := Compile(l, attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	rp, err := Compile(r, attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lp, rp, nil
+}
+
+// StringAttr compares a text column case-insensitively, as SCIM does for
+// attributes that are not caseExact.
+func StringAttr(column string) Attr {
+	return func(op string, value any) (func(*sql.Selector), error) {
+		if op == "pr" {
+			return sql.AndPredicates(sql.FieldNotNull(column), sql.FieldNEQ(column, "")), nil
+		}
+		v, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s compares against strings", column)
+		}
+		lower := strings.ToLower(v)
+		switch op {
+		case "eq":
+			return sql.FieldEqualFold(column, v), nil
+		case "ne":
+			return sql.NotPredicates(sql.FieldEqualFold(column, v)), nil
+		case "co":
+			return sql.FieldContainsFold(column, v), nil
+		case "sw":
+			return func(s *sql.Selector) { s.Where(sql.HasPrefix(sql.Lower(s.C(column)), lower)) }, nil
+		case "ew":
+			return func(s *sql.Selector) { s.Where(sql.HasSuffix(sql.Lower(s.C(column)), lower)) }, nil
+		case "gt":
+			return sql.FieldGT(column, v), nil
+		case "ge":
+			return sql.FieldGTE(column, v), nil
+		case "lt":
+			return sql.FieldLT(column, v), nil
+		case "le":
+			return sql.FieldLTE(column, v), nil
+		}
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+}
+
+// BoolAttr compares a boolean column; only eq, ne and pr apply.
+func BoolAttr(column string) Attr {
+	return func(op string, value any) (func(*sql.Selector), error) {
+		if op == "pr" {
+			return sql.FieldNotNull(column), nil
+		}
+		v, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s compares against true or false", column)
+		}
+		switch op {
+		case "eq":
+			return sql.FieldEQ(column, v), nil
+		case "ne":
+			return sql.FieldNEQ(column, v), nil
+		}
+		return nil, fmt.Errorf("unsupported operator %q for a boolean", op)
+	}
+}
+
+// IDAttr compares an integer primary key given as a SCIM id string; only
+// eq, ne and pr apply. Ids that aren't numbers match nothing.
+func IDAttr(column string) Attr {
+	return func(op string, value any) (func(*sql.Selector), error) {
+		if op == "pr" {
+			return sql.FieldNotNull(column), nil
+		}
+		if op != "eq" && op != "ne" {
+			return nil, fmt.Errorf("unsupported operator %q for id", op)
+		}
+		s, _ := value.(string)
+		id, err := ParseID(s)
+		if err != nil {
+			if op == "eq" {
+				return func(s *sql.Selector) { s.Where(sql.False()) }, nil
+			}
+			return func(*sql.Selector) {}, nil
+		}
+		if op == "eq" {
+			return sql.FieldEQ(column, id), nil
+		}
+		return sql.FieldNEQ(column, id), nil
+	}
+}
+
+// Match evaluates f against a JSON object such as a multi-valued attribute
+// entry, matching attribute names case-insensitively.
+func Match(f Filter, item map[string]any) bool {
+	switch f := f.(type) {
+	case Compare:
+		return compare(lookup(item, f.Attr), f.Op, f.Value)
+	case And:
+		return Match(f.L, item) && Match(f.R, item)
+	case Or:
+		return Match(f.L, item) || Match(f.R, item)
+	case Not:
+		return !Match(f.X, item)
+	}
+	return false
+}
+
+// lookup returns the value at a dotted path, case-insensitively.
+func lookup(doc map[string]any, path string) any {
+	var cur any = doc
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = nil
+		for k, v := range m {
+			if strings.EqualFold(k, part) {
+				cur = v
+				break
+			}
+		}
+	}
+	return cur
+}
+
+func compare(have any, op string, want any) bool {
+	if op == "pr" {
+		return have != nil && have != ""
+	}
+	switch h := have.(type) {
+	case string:
+		w, ok := want.(string)
+		if !ok {
+			return false
+		}
+		h, w = strings.ToLower(h), strings.ToLower(w)
+		switch op {
+		case "eq":
+			return h == w
+		case "ne":
+			return h != w
+		case "co":
+			return strings.Contains(h, w)
+		case "sw":
+			return strings.HasPrefix(h, w)
+		case "ew":
+			return strings.HasSuffix(h, w)
+		case "gt":
+			return h > w
+		case "ge":
+			return h >= w
+		case "lt":
+			return h < w
+		case "le":
+			return h <= w
+		}
+	case bool:
+		switch op {
+		case "eq":
+			return h == want
+		case "ne":
+			return h != want
+		}
+	case float64:
+		w, ok := want.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return h == w
+		case "ne":
+			return h != w
+		case "gt":
+			return h > w
+		case "ge":
+			return h >= w
+		case "lt":
+			return h < w
+		case "le":
+			return h <= w
+		}
+	case nil:
+		return op == "ne" && want != nil
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+// internal/scim/patch.go
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PatchRequest is the body of a PATCH (RFC 7644 section 3.5.2).
+type PatchRequest struct {
+	Schemas    []string  `json:"schemas"`
+	Operations []PatchOp `json:"Operations"`
+}
+
+// PatchOp is one add, replace or remove operation.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// path is a parsed PATCH path: attr, attr.sub, attr[filter] or attr[filter].sub.
+type path struct {
+	attr   string
+	filter Filter
+	sub    string
+}
+
+func parsePath(s string) (path, error) {
+	var p path
+	head, rest, bracketed := strings.Cut(s, "[")
+	if bracketed {
+		inner, tail, ok := strings.Cut(rest, "]")
+		if !ok {
+			return p, BadRequest(ErrInvalidPath, "unterminated [ in path "+s)
+		}
+		f, err := ParseFilter(inner)
+		if err != nil {
+			return p, BadRequest(ErrInvalidPath, fmt.Sprintf("path %s: %v", s, err))
+		}
+		p.filter = f
+		if tail != "" {
+			sub, ok := strings.CutPrefix(tail, ".")
+			if !ok || sub == "" {
+				return p, BadRequest(ErrInvalidPath, "invalid path "+s)
+			}
+			p.sub = strings.ToLower(sub)
+		}
+	}
+	p.attr = AttrName(head)
+	if !validAttr(p.attr) || (bracketed && strings.Contains(p.attr, ".")) {
+		return p, BadRequest(ErrInvalidPath, "invalid path "+s)
+	}
+	return p, nil
+}
+
+// ApplyPatch applies ops to doc, the JSON form of a resource, so the
+// result can be decoded and saved like a PUT. Attribute names match
+// case-insensitively. Removing values that aren't there is not an error.
+func ApplyPatch(doc map[string]any, ops []PatchOp) error {
+	for _, op := range ops {
+		kind := strings.ToLower(op.Op)
+		if kind != "add" && kind != "replace" && kind != "remove" {
+			return BadRequest(ErrInvalidSyntax, fmt.Sprintf("unknown op %q", op.Op))
+		}
+		var value any
+		if kind != "remove" {
+			if len(op.Value) == 0 {
+				return BadRequest(ErrInvalidValue, op.Op+" requires a value")
+			}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return BadRequest(ErrInvalidSyntax, "invalid value")
+			}
+		}
+
+		// without a path the value is an object of attributes to add or replace
+		if op.Path == "" {
+			obj, ok := value.(map[string]any)
+			if kind == "remove" || !ok {
+				return BadRequest(ErrNoTarget, op.Op+" without a path requires an object value")
+			}
+			for k, v := range obj {
+				p, err := parsePath(k)
+				if err != nil {
+					return err
+				}
+				if err := apply(doc, kind, p, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		p, err := parsePath(op.Path)
+		if err != nil {
+			return err
+		}
+		if err := apply(doc, kind, p, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(doc map[string]any, kind string, p path, value any) error {
+	if p.filter == nil {
+		return applyAttr(doc, kind, p.attr, value)
+	}
+
+	// attr[filter] addresses entries of a multi-valued attribute
+	k := key(doc, p.attr)
+	items, _ := doc[k].([]any)
+	var matched []int
+	for i, it := range items {
+		if m, ok := it.(map[string]any); ok && Match(p.filter, m) {
+			matched = append(matched, i)
+		}
+	}
+	switch {
+	case kind == "remove" && p.sub == "":
+		kept := items[:0:0]
+		for i, it := range items {
+			if !slices.Contains(matched, i) {
+				kept = append(kept, it)
+			}
+		}
+		doc[k] = kept
+	case kind == "remove":
+		for _, i := range matched {
+			delete(items[i].(map[string]any), key(items[i].(map[string]any), p.sub))
+		}
+	case len(matched) == 0 && p.sub != "":
+		// nothing to update yet: create the entry the filter describes
+		c, ok := p.filter.(Compare)
+		if !ok || c.Op != "eq" || strings.Contains(c.Attr, ".") {
+			return BadRequest(ErrNoTarget, "no value matches "+p.attr+" filter")
+		}
+		doc[k] = append(items, map[string]any{c.Attr: c.Value, p.sub: value})
+	case len(matched) == 0:
+		return BadRequest(ErrNoTarget, "no value matches "+p.attr+" filter")
+	case p.sub != "":
+		for _, i := range matched {
+			m := items[i].(map[string]any)
+			m[key(m, p.sub)] = value
+		}
+	default:
+		if _, ok := value.(map[string]any); !ok {
+			return BadRequest(ErrInvalidValue, p.attr+" entries are objects")
+		}
+		for _, i := range matched {
+			items[i] = value
+		}
+	}
+	return nil
+}
+
+// applyAttr handles attr and attr.sub paths.
+func applyAttr(doc map[string]any, kind, attr string, value any) error {
+	parent := doc
+	name := attr
+	if head, sub, ok := strings.Cut(attr, "."); ok {
+		k := key(doc, head)
+		m, ok := doc[k].(map[string]any)
+		if !ok {
+			if kind == "remove" {
+				return nil
+			}
+			m = map[string]any{}
+			doc[k] = m
+		}
+		parent, name = m, sub
+	}
+	k := key(parent, name)
+	switch kind {
+	case "remove":
+		delete(parent, k)
+	case "add":
+		// adding to a multi-valued attribute appends, skipping duplicates
+		if have, ok := parent[k].([]any); ok {
+			add, ok := value.([]any)
+			if !ok {
+				add = []any{value}
+			}
+			for _, v := range add {
+				if !containsValue(have, v) {
+					have = append(have, v)
+				}
+			}
+			parent[k] = have
+			return nil
+		}
+		parent[k] = value
+	default:
+		parent[k] = value
+	}
+	return nil
+}
+
+// key returns the key of m matching name case-insensitively, or name.
+func key(m map[string]any, name string) string {
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
+
+// containsValue reports whether list holds v, comparing entries with a
+// "value" sub-attribute by that alone.
+func containsValue(list []any, v any) bool {
+	vm, _ := v.(map[string]any)
+	for _, it := range list {
+		im, _ := it.(map[string]any)
+		if vm != nil && im != nil {
+			if lookup(vm, "value") != nil && fmt.Sprint(lookup(vm, "value")) == fmt.Sprint(lookup(im, "value")) {
+				return true
+			}
+			continue
+		}
+		if fmt.Sprint(it) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+// internal/scim/scim.go
+package scim
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Schema URNs used on the wire.
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// ContentType is the media type of SCIM requests and responses.
+const ContentType = "application/scim+json"
+
+// scimType values for 400 and 409 errors (RFC 7644 section 3.12).
+const (
+	ErrInvalidFilter = "invalidFilter"
+	ErrInvalidSyntax = "invalidSyntax"
+	ErrInvalidPath   = "invalidPath"
+	ErrInvalidValue  = "invalidValue"
+	ErrNoTarget      = "noTarget"
+	ErrUniqueness    = "uniqueness"
+)
+
+// Error is a SCIM error response body. It doubles as a Go error so parsing
+// helpers can say which scimType a failure maps to.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// BadRequest returns a 400 error of the given scimType.
+func BadRequest(scimType, detail string) *Error {
+	return &Error{Schemas: []string{SchemaError}, Status: "400", ScimType: scimType, Detail: detail}
+}
+
+// WriteError answers with a SCIM error body.
+func WriteError(w http.ResponseWriter, status int, scimType, detail string) {
+	WriteJSON(w, status, &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// WriteJSON answers with v as application/scim+json.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed encoding response: %v", err)
+	}
+}
+
+// FormatID renders an entity ID as a SCIM id.
+func FormatID(id int) string {
+	return strconv.Itoa(id)
+}
+
+// ParseID parses a SCIM id produced by FormatID.
+func ParseID(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// Meta is the common "meta" attribute.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+}
+
+// ListResponse wraps the results of a query.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// Bool is a boolean that also accepts "True" and "False" strings, as some
+// provisioning clients send them.
+type Bool bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = Bool(v)
+		return nil
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return BadRequest(ErrInvalidValue, "expected a boolean, got "+strconv.Quote(v))
+		}
+		*b = Bool(parsed)
+		return nil
+	}
+	return BadRequest(ErrInvalidValue, "expected a boolean")
+}
+
+// Email is an entry of a user's "emails".
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary Bool   `json:"primary,omitempty"`
+}
+
+// Ref points at another resource, as in a user's "groups" or a group's "members".
+type Ref struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// User is the User resource. Attributes this service doesn't store, such
+// as "name", are accepted and dropped.
+type User struct {
+	Schemas    []string `json:"schemas"`
+	ID         string   `json:"id,omitempty"`
+	ExternalID *string  `json:"externalId,omitempty"`
+	UserName   string   `json:"userName"`
+	Active     *Bool    `json:"active,omitempty"`
+	Emails     []Email  `json:"emails,omitempty"`
+	Password   string   `json:"password,omitempty"`
+	// Groups is read-only; membership is managed through Groups.
+	Groups []Ref `json:"groups,omitempty"`
+	Meta   *Meta `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary address, or the first one.
+func (u *User) PrimaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Group is the Group resource.
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  *string  `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Ref    `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// Page reads startIndex (1-based) and count from a list query, capping
+// count at max.
+func Page(r *http.Request, max int) (offset, limit int) {
+	q := r.URL.Query()
+	start, err := strconv.Atoi(q.Get("startIndex"))
+	if err != nil || start < 1 {
+		start = 1
+	}
+	limit, err = strconv.Atoi(q.Get("count"))
+	if err != nil || limit > max {
+		limit = max
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	return start - 1, limit
+}
@@ -0,0 +1,319 @@
+// internal/session/session.go
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/predicate"
+	"pollAppNew/ent/session"
+)
+
+// CookieName is the cookie carrying the opaque session token.
+const CookieName = "session"
+
+// touchInterval throttles last_seen_at writes so every request doesn't hit the DB.
+const touchInterval = time.Minute
+
+// maxChallengeAttempts is how many wrong second-factor codes an mfa
+// challenge tolerates before it is revoked and the password must be re-entered.
+const maxChallengeAttempts = 5
+
+var (
+	ttl          = flag.Duration("session-ttl", 24*time.Hour, "lifetime of a login session")
+	refreshTTL   = flag.Duration("refresh-token-ttl", 30*24*time.Hour, "lifetime of an API refresh token")
+	challengeTTL = flag.Duration("mfa-challenge-ttl", 5*time.Minute, "how long a correct password waits for its second factor")
+	sameSite     = flag.String("cookie-samesite", "lax", `SameSite policy of the session and CSRF cookies: "lax", "strict" or "none" (none requires HTTPS)`)
+)
+
+var (
+	// ErrInvalid is returned when a token is unknown, expired or revoked.
+	ErrInvalid = errors.New("invalid or expired session")
+	// ErrNotFound is returned when revoking a session the user doesn't own.
+	ErrNotFound = errors.New("session not found")
+)
+
+// NewToken returns a random URL-safe token and the hash under which it is stored.
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns the hex SHA-256 of token, as stored in the database.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create starts a new cookie session for userID and returns the token to hand to the client.
+func Create(ctx context.Context, client *ent.Client, userID int, r *http.Request) (string, *ent.Session, error) {
+	return create(ctx, client, userID, session.KindCookie, *ttl, r)
+}
+
+// CreateRefresh starts a new API session for userID and returns its refresh token.
+func CreateRefresh(ctx context.Context, client *ent.Client, userID int, r *http.Request) (string, *ent.Session, error) {
+	return create(ctx, client, userID, session.KindRefresh, *refreshTTL, r)
+}
+
+// CreateChallenge records that userID passed the password check and still
+// owes a second factor, and returns the token to redeem it with.
+func CreateChallenge(ctx context.Context, client *ent.Client, userID int, r *http.Request) (string, *ent.Session, error) {
+	return create(ctx, client, userID, session.KindMfa, *challengeTTL, r)
+}
+
+func create(ctx context.Context, client *ent.Client, userID int, kind session.Kind, ttl time.Duration, r *http.Request) (string, *ent.Session, error) {
+	token, hash, err := NewToken()
+	if err != nil {
+		return "", nil, err
+	}
+	s, err := client.Session.
+		Create().
+		SetTokenHash(hash).
+		SetUserID(userID).
+		SetKind(kind).
+		SetUserAgent(r.UserAgent()).
+		SetIP(ClientIP(r)).
+		SetExpiresAt(time.Now().Add(ttl)).
+		Save(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, s, nil
+}
+
+// Lookup resolves a cookie token to its session, with the owning user loaded.
+// Expired sessions are deleted and reported as ErrInvalid.
+func Lookup(ctx context.Context, client *ent.Client, token string) (*ent.Session, error) {
+	if token == "" {
+		return nil, ErrInvalid
+	}
+	return check(ctx, client, session.TokenHashEQ(HashToken(token)), session.KindEQ(session.KindCookie))
+}
+
+// LookupRefresh resolves a refresh token to its session, with the owning user loaded.
+func LookupRefresh(ctx context.Context, client *ent.Client, token string) (*ent.Session, error) {
+	if token == "" {
+		return nil, ErrInvalid
+	}
+	return check(ctx, client, session.TokenHashEQ(HashToken(token)), session.KindEQ(session.KindRefresh))
+}
+
+// LookupChallenge resolves an mfa challenge token, with the owning user loaded.
+func LookupChallenge(ctx context.Context, client *ent.Client, token string) (*ent.Session, error) {
+	if token == "" {
+		return nil, ErrInvalid
+	}
+	return check(ctx, client, session.TokenHashEQ(HashToken(token)), session.KindEQ(session.KindMfa))
+}
+
+// FailChallenge counts a wrong code against s, revoking it once
+// maxChallengeAttempts is reached.
+func FailChallenge(ctx context.Context, client *ent.Client, s *ent.Session) error {
+	if s.Attempts+1 >= maxChallengeAttempts {
+		return Revoke(ctx, client, s.ID)
+	}
+	return client.Session.
+		UpdateOneID(s.ID).
+		AddAttempts(1).
+		Exec(ctx)
+}
+
+// LookupID resolves a refresh session by ID, as referenced by an access token's sid claim.
+func LookupID(ctx context.Context, client *ent.Client, id int) (*ent.Session, error) {
+	return check(ctx, client, session.IDEQ(id), session.KindEQ(session.KindRefresh))
+}
+
+// Rotate replaces the token of a refresh session and extends its expiry,
+// so every refresh token can be used only once. The update is conditioned on
+// the token hash s was looked up by; if a concurrent Rotate got there first,
+// the token is already spent and ErrInvalid is returned.
+func Rotate(ctx context.Context, client *ent.Client, s *ent.Session) (string, *ent.Session, error) {
+	token, hash, err := NewToken()
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	n, err := client.Session.
+		Update().
+		Where(session.IDEQ(s.ID), session.TokenHashEQ(s.TokenHash)).
+		SetTokenHash(hash).
+		SetLastSeenAt(now).
+		SetExpiresAt(now.Add(*refreshTTL)).
+		Save(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if n == 0 {
+		return "", nil, ErrInvalid
+	}
+	s, err = client.Session.Get(ctx, s.ID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil, ErrInvalid
+		}
+		return "", nil, err
+	}
+	return token, s, nil
+}
+
+func check(ctx context.Context, client *ent.Client, ps ...predicate.Session) (*ent.Session, error) {
+	s, err := client.Session.
+		Query().
+		Where(ps...).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrInvalid
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if now.After(s.ExpiresAt) || s.Edges.User == nil {
+		if err := client.Session.DeleteOneID(s.ID).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, ErrInvalid
+	}
+	if now.Sub(s.LastSeenAt) > touchInterval {
+		if err := client.Session.
+			UpdateOneID(s.ID).
+			SetLastSeenAt(now).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		s.LastSeenAt = now
+	}
+	return s, nil
+}
+
+// FromRequest resolves the session named by the request's session cookie.
+func FromRequest(ctx context.Context, client *ent.Client, r *http.Request) (*ent.Session, error) {
+	c, err := r.Cookie(CookieName)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	return Lookup(ctx, client, c.Value)
+}
+
+// Revoke deletes the session with the given ID.
+func Revoke(ctx context.Context, client *ent.Client, id int) error {
+	return client.Session.DeleteOneID(id).Exec(ctx)
+}
+
+// SameSite returns the SameSite mode chosen with -cookie-samesite.
+func SameSite() http.SameSite {
+	switch *sameSite {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// CheckSameSite validates -cookie-samesite.
+func CheckSameSite() error {
+	switch *sameSite {
+	case "lax", "strict", "none":
+		return nil
+	}
+	return fmt.Errorf("-cookie-samesite: unknown mode %q", *sameSite)
+}
+
+// SetCookie writes the session cookie for token.
+func SetCookie(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   SameSite() == http.SameSiteNoneMode,
+		SameSite: SameSite(),
+		Expires:  expires,
+	})
+}
+
+// ClearCookie overwrites the session cookie with an expired one.
+func ClearCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   SameSite() == http.SameSiteNoneMode,
+		SameSite: SameSite(),
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+	})
+}
+
+// ClientIP returns the IP address of the peer r came from.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ListForUser returns the unexpired sessions of userID, most recently used
+// first. Pending mfa challenges are not sessions yet and are left out.
+func ListForUser(ctx context.Context, client *ent.Client, userID int) ([]*ent.Session, error) {
+	return client.Session.
+		Query().
+		Where(
+			session.UserIDEQ(userID),
+			session.KindNEQ(session.KindMfa),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(session.FieldLastSeenAt)).
+		All(ctx)
+}
+
+// RevokeForUser deletes session id if it belongs to userID. It returns
+// ErrNotFound when no such session exists for that user.
+func RevokeForUser(ctx context.Context, client *ent.Client, userID, id int) error {
+	n, err := client.Session.
+		Delete().
+		Where(session.IDEQ(id), session.UserIDEQ(userID)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RevokeOthers deletes every session of userID except keepID and reports how many were removed.
+func RevokeOthers(ctx context.Context, client *ent.Client, userID, keepID int) (int, error) {
+	return client.Session.
+		Delete().
+		Where(session.UserIDEQ(userID), session.IDNEQ(keepID)).
+		Exec(ctx)
+}
+
+// RevokeAll deletes every session of userID, e.g. after a password reset.
+func RevokeAll(ctx context.Context, client *ent.Client, userID int) (int, error) {
+	return client.Session.
+		Delete().
+		Where(session.UserIDEQ(userID)).
+		Exec(ctx)
+}
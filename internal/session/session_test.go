@@ -0,0 +1,51 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"pollAppNew/ent/enttest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Two refreshes presenting the same token must not both succeed, even when
+// both looked the session up before either rotated it.
+func TestRotateIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:rotate?mode=memory&_fk=1")
+	defer client.Close()
+	u := client.User.Create().SetUsername("alice").SaveX(ctx)
+
+	token, _, err := CreateRefresh(ctx, client, u.ID, httptest.NewRequest("POST", "/auth/token", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := LookupRefresh(ctx, client, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := LookupRefresh(ctx, client, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, s, err := Rotate(ctx, client, first)
+	if err != nil {
+		t.Fatalf("first rotate: %v", err)
+	}
+	if s.ID != first.ID || s.TokenHash != HashToken(next) {
+		t.Fatalf("rotated session = %d/%s, want %d/%s", s.ID, s.TokenHash, first.ID, HashToken(next))
+	}
+	if _, _, err := Rotate(ctx, client, second); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("second rotate: err = %v, want ErrInvalid", err)
+	}
+	if _, err := LookupRefresh(ctx, client, token); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("old token still resolves: %v", err)
+	}
+	if _, err := LookupRefresh(ctx, client, next); err != nil {
+		t.Fatalf("new token: %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubProvider is a minimal OpenID provider: discovery, JWKS and a token
+// endpoint that checks the PKCE verifier of each code it issued.
+type stubProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]stubGrant
+}
+
+type stubGrant struct {
+	challenge string
+	claims    map[string]interface{}
+	key       *rsa.PrivateKey // signs the ID token; nil for the provider's key
+}
+
+func newStubProvider(t *testing.T) *stubProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := &stubProvider{key: key, codes: map[string]stubGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                sp.URL,
+			"authorization_endpoint":                sp.URL + "/auth",
+			"token_endpoint":                        sp.URL + "/token",
+			"jwks_uri":                              sp.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "k1",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   b64(key.N.Bytes()),
+				"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		sp.mu.Lock()
+		g, ok := sp.codes[r.FormValue("code")]
+		delete(sp.codes, r.FormValue("code"))
+		sp.mu.Unlock()
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || b64(sum[:]) != g.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		signer := g.key
+		if signer == nil {
+			signer = sp.key
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "at",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     signJWT(t, signer, g.claims),
+		})
+	})
+	sp.Server = httptest.NewServer(mux)
+	t.Cleanup(sp.Close)
+	return sp
+}
+
+// authorize plays the user signing in at the provider: it accepts the
+// authorization URL and issues a code for an ID token with claims, on top
+// of the standard ones.
+func (sp *stubProvider) authorize(t *testing.T, authURL string, claims map[string]interface{}, key *rsa.PrivateKey) string {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("code_challenge_method = %q, want S256", q.Get("code_challenge_method"))
+	}
+	all := map[string]interface{}{
+		"iss":   sp.URL,
+		"sub":   "sub-1",
+		"aud":   q.Get("client_id"),
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": q.Get("nonce"),
+	}
+	for k, v := range claims {
+		all[k] = v
+	}
+	code := "code-" + b64([]byte(q.Get("state")))
+	sp.mu.Lock()
+	sp.codes[code] = stubGrant{challenge: q.Get("code_challenge"), claims: all, key: key}
+	sp.mu.Unlock()
+	return code
+}
+
+func signJWT(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := b64(header) + "." + b64(payload)
+	sum := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + b64(sig)
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestOIDCExchange(t *testing.T) {
+	ctx := context.Background()
+	sp := newStubProvider(t)
+	p, err := NewOIDC(ctx, OIDCConfig{
+		Issuer:        sp.URL,
+		ClientID:      "pollapp",
+		ClientSecret:  "secret",
+		RedirectURL:   "http://localhost/auth/oidc/callback",
+		UsernameClaim: "preferred_username",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		claims   map[string]interface{}
+		key      *rsa.PrivateKey
+		verifier string // sent at exchange; empty for the one sent at login
+		nonce    string // expected at exchange; empty for the one sent at login
+		wantErr  string
+	}{
+		{name: "ok", claims: map[string]interface{}{"preferred_username": "alice", "email": "alice@example.com", "email_verified": true}},
+		{name: "wrong PKCE verifier", verifier: "not-the-verifier-not-the-verifier-not-the-ver", wantErr: "exchanging code"},
+		{name: "nonce mismatch", nonce: "other-nonce", wantErr: "nonce mismatch"},
+		{name: "missing nonce", claims: map[string]interface{}{"nonce": ""}, wantErr: "nonce mismatch"},
+		{name: "foreign signing key", key: otherKey, wantErr: "verifying id_token"},
+		{name: "wrong audience", claims: map[string]interface{}{"aud": "someone-else"}, wantErr: "verifying id_token"},
+		{name: "expired", claims: map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}, wantErr: "verifying id_token"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, nonce, verifier := fmt.Sprintf("state-%d", i), "nonce-1", "verifier-verifier-verifier-verifier-verifier-1"
+			code := sp.authorize(t, p.AuthCodeURL(state, nonce, verifier), tt.claims, tt.key)
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+			c, err := p.Exchange(ctx, code, verifier, nonce)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Claims{Subject: "sub-1", Username: "alice", Email: "alice@example.com", EmailVerified: true}
+			if *c != want {
+				t.Errorf("claims = %+v, want %+v", *c, want)
+			}
+		})
+	}
+
+	// a code is redeemed once
+	code := sp.authorize(t, p.AuthCodeURL("replay", "n", "verifier-verifier-verifier-verifier-verifier-2"), nil, nil)
+	if _, err := p.Exchange(ctx, code, "verifier-verifier-verifier-verifier-verifier-2", "n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Exchange(ctx, code, "verifier-verifier-verifier-verifier-verifier-2", "n"); err == nil {
+		t.Fatal("replayed code accepted")
+	}
+}
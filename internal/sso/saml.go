@@ -0,0 +1,274 @@
+// internal/sso/saml.go
+package sso
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+var (
+	samlIDPMetadataURL    = flag.String("saml-idp-metadata-url", "", "URL of the SAML identity provider's metadata; enables SAML login when set")
+	samlIDPMetadataFile   = flag.String("saml-idp-metadata-file", "", "file holding the SAML identity provider's metadata; alternative to -saml-idp-metadata-url")
+	samlRootURL           = flag.String("saml-root-url", "", "absolute base URL of this API; the IdP is given <root>/auth/saml/metadata and <root>/auth/saml/acs")
+	samlCertFile          = flag.String("saml-cert", "", "PEM certificate of the service provider")
+	samlKeyFile           = flag.String("saml-key", "", "PEM RSA private key of -saml-cert; signs AuthnRequests and decrypts assertions")
+	samlSubjectAttr       = flag.String("saml-subject-attr", "", "assertion attribute holding a stable user ID; empty uses the persistent NameID")
+	samlUsernameAttr      = flag.String("saml-username-attr", "uid", "assertion attribute used as the local username")
+	samlEmailAttr         = flag.String("saml-email-attr", "mail", "assertion attribute holding the user's email address")
+	samlAllowIDPInitiated = flag.Bool("saml-allow-idp-initiated", false, "accept unsolicited responses from logins started at the identity provider")
+	samlLinkExisting      = flag.Bool("saml-link-existing", false, "link a first SAML login to an existing local user with the same verified email")
+)
+
+// SAMLConfig configures a SAML 2.0 service provider. Tests can build one by
+// hand to use locally generated keys and IdP metadata instead of the flags.
+type SAMLConfig struct {
+	IDPMetadataURL    string
+	IDPMetadataFile   string
+	RootURL           string
+	CertFile          string
+	KeyFile           string
+	SubjectAttr       string
+	UsernameAttr      string
+	EmailAttr         string
+	AllowIDPInitiated bool
+	LinkExisting      bool
+}
+
+// SAMLConfigFromFlags returns the configuration given on the command line.
+func SAMLConfigFromFlags() SAMLConfig {
+	return SAMLConfig{
+		IDPMetadataURL:    *samlIDPMetadataURL,
+		IDPMetadataFile:   *samlIDPMetadataFile,
+		RootURL:           *samlRootURL,
+		CertFile:          *samlCertFile,
+		KeyFile:           *samlKeyFile,
+		SubjectAttr:       *samlSubjectAttr,
+		UsernameAttr:      *samlUsernameAttr,
+		EmailAttr:         *samlEmailAttr,
+		AllowIDPInitiated: *samlAllowIDPInitiated,
+		LinkExisting:      *samlLinkExisting,
+	}
+}
+
+// SAML is a service provider trusting one identity provider, using the
+// HTTP-Redirect binding for requests and HTTP-POST for responses.
+type SAML struct {
+	cfg SAMLConfig
+	sp  *saml.ServiceProvider
+
+	// assertion IDs already redeemed, until they expire, so a captured
+	// response can't be posted twice
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewSAML loads the SP key pair and the IdP metadata. It returns nil, nil
+// when no IdP metadata is configured, meaning SAML login is disabled.
+func NewSAML(ctx context.Context, cfg SAMLConfig) (*SAML, error) {
+	if cfg.IDPMetadataURL == "" && cfg.IDPMetadataFile == "" {
+		return nil, nil
+	}
+	if cfg.RootURL == "" || cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("saml: root URL, certificate and key are required")
+	}
+
+	// 1) Our key pair
+	pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("saml: loading key pair: %w", err)
+	}
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("saml: key must be RSA")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("saml: parsing certificate: %w", err)
+	}
+
+	// 2) The identity provider's metadata
+	var idp *saml.EntityDescriptor
+	if cfg.IDPMetadataFile != "" {
+		raw, err := os.ReadFile(cfg.IDPMetadataFile)
+		if err != nil {
+			return nil, fmt.Errorf("saml: reading IdP metadata: %w", err)
+		}
+		idp, err = samlsp.ParseMetadata(raw)
+		if err != nil {
+			return nil, fmt.Errorf("saml: parsing IdP metadata: %w", err)
+		}
+	} else {
+		u, err := url.Parse(cfg.IDPMetadataURL)
+		if err != nil {
+			return nil, fmt.Errorf("saml: IdP metadata URL: %w", err)
+		}
+		idp, err = samlsp.FetchMetadata(ctx, http.DefaultClient, *u)
+		if err != nil {
+			return nil, fmt.Errorf("saml: fetching IdP metadata: %w", err)
+		}
+	}
+
+	// 3) Our endpoints
+	root, err := url.Parse(strings.TrimRight(cfg.RootURL, "/"))
+	if err != nil || !root.IsAbs() {
+		return nil, fmt.Errorf("saml: root URL %q must be absolute", cfg.RootURL)
+	}
+	metadataURL := *root
+	metadataURL.Path += "/auth/saml/metadata"
+	acsURL := *root
+	acsURL.Path += "/auth/saml/acs"
+
+	nameIDFormat := saml.PersistentNameIDFormat
+	if cfg.SubjectAttr != "" {
+		nameIDFormat = saml.UnspecifiedNameIDFormat
+	}
+	return &SAML{
+		cfg: cfg,
+		sp: &saml.ServiceProvider{
+			EntityID:          metadataURL.String(),
+			Key:               key,
+			Certificate:       cert,
+			MetadataURL:       metadataURL,
+			AcsURL:            acsURL,
+			IDPMetadata:       idp,
+			AuthnNameIDFormat: nameIDFormat,
+			AllowIDPInitiated: cfg.AllowIDPInitiated,
+			SignatureMethod:   dsig.RSASHA256SignatureMethod,
+		},
+		seen: map[string]time.Time{},
+	}, nil
+}
+
+// Issuer identifies the identity provider in Identity rows.
+func (p *SAML) Issuer() string {
+	return p.sp.IDPMetadata.EntityID
+}
+
+// LinkExisting reports whether first logins may claim the local user with
+// the same verified email.
+func (p *SAML) LinkExisting() bool {
+	return p.cfg.LinkExisting
+}
+
+// SecureCookies reports whether the API is served over HTTPS, so cookies
+// that must survive the IdP's cross-site POST can be SameSite=None.
+func (p *SAML) SecureCookies() bool {
+	return p.sp.AcsURL.Scheme == "https"
+}
+
+// Metadata returns the SP metadata document to register with the IdP.
+func (p *SAML) Metadata() ([]byte, error) {
+	return xml.MarshalIndent(p.sp.Metadata(), "", "  ")
+}
+
+// AuthnRequestURL returns the IdP URL to send the browser to, carrying a
+// signed AuthnRequest, and the request's ID to expect in the response.
+func (p *SAML) AuthnRequestURL(relayState string) (string, string, error) {
+	req, err := p.sp.MakeAuthenticationRequest(
+		p.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding,
+		saml.HTTPPostBinding,
+	)
+	if err != nil {
+		return "", "", err
+	}
+	u, err := req.Redirect(relayState, p.sp)
+	if err != nil {
+		return "", "", err
+	}
+	return u.String(), req.ID, nil
+}
+
+// ParseResponse validates the SAMLResponse posted to the ACS: signature,
+// audience, destination, validity window and, unless IdP-initiated logins
+// are allowed, that it answers one of requestIDs. It returns the claims
+// mapped from the assertion.
+func (p *SAML) ParseResponse(r *http.Request, requestIDs []string) (*Claims, error) {
+	// 1) Validate the response
+	assertion, err := p.sp.ParseResponse(r, requestIDs)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) {
+			return nil, fmt.Errorf("saml: %w", invalid.PrivateErr)
+		}
+		return nil, fmt.Errorf("saml: %w", err)
+	}
+
+	// 2) Each assertion is good for one login
+	if err := p.redeem(assertion); err != nil {
+		return nil, err
+	}
+
+	// 3) Map attributes onto claims
+	c := &Claims{
+		Username: attribute(assertion, p.cfg.UsernameAttr),
+		Email:    attribute(assertion, p.cfg.EmailAttr),
+		// the IdP is authoritative for its users' addresses
+		EmailVerified: true,
+	}
+	if p.cfg.SubjectAttr != "" {
+		c.Subject = attribute(assertion, p.cfg.SubjectAttr)
+	} else if assertion.Subject != nil && assertion.Subject.NameID != nil &&
+		assertion.Subject.NameID.Format != string(saml.TransientNameIDFormat) {
+		c.Subject = assertion.Subject.NameID.Value
+	}
+	if c.Subject == "" {
+		return nil, errors.New("saml: assertion has no persistent subject; set -saml-subject-attr")
+	}
+	return c, nil
+}
+
+// redeem records the assertion's ID until it expires, failing if it was
+// already used.
+func (p *SAML) redeem(a *saml.Assertion) error {
+	expires := time.Now().Add(saml.MaxIssueDelay)
+	if a.Conditions != nil && !a.Conditions.NotOnOrAfter.IsZero() {
+		expires = a.Conditions.NotOnOrAfter
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for id, exp := range p.seen {
+		if now.After(exp) {
+			delete(p.seen, id)
+		}
+	}
+	if _, ok := p.seen[a.ID]; ok {
+		return errors.New("saml: assertion already used")
+	}
+	p.seen[a.ID] = expires
+	return nil
+}
+
+// attribute returns the first value of the attribute called name, matched
+// against both its Name and FriendlyName.
+func attribute(a *saml.Assertion, name string) string {
+	if name == "" {
+		return ""
+	}
+	for _, st := range a.AttributeStatements {
+		for _, attr := range st.Attributes {
+			if (attr.Name == name || attr.FriendlyName == name) && len(attr.Values) > 0 {
+				return attr.Values[0].Value
+			}
+		}
+	}
+	return ""
+}
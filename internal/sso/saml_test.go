@@ -0,0 +1,249 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+)
+
+const (
+	spRoot  = "https://polls.example.com"
+	idpRoot = "https://idp.example.com"
+)
+
+// selfSigned returns a fresh RSA key and a certificate for it.
+func selfSigned(t *testing.T, name string) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+// newTestIDP returns an identity provider at idpRoot signing with a fresh key.
+func newTestIDP(t *testing.T) *saml.IdentityProvider {
+	t.Helper()
+	key, cert := selfSigned(t, "idp")
+	metadataURL, _ := url.Parse(idpRoot + "/metadata")
+	ssoURL, _ := url.Parse(idpRoot + "/sso")
+	return &saml.IdentityProvider{Key: key, Certificate: cert, MetadataURL: *metadataURL, SSOURL: *ssoURL}
+}
+
+// newTestSAML returns a service provider at spRoot that trusts idp.
+func newTestSAML(t *testing.T, idp *saml.IdentityProvider, allowIDPInitiated bool) *SAML {
+	t.Helper()
+	dir := t.TempDir()
+	key, cert := selfSigned(t, "sp")
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	md, err := xml.Marshal(idp.Metadata())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewSAML(context.Background(), SAMLConfig{
+		IDPMetadataFile:   write("idp.xml", md),
+		RootURL:           spRoot,
+		CertFile:          write("sp.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		KeyFile:           write("sp.key", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		UsernameAttr:      "uid",
+		EmailAttr:         "mail",
+		AllowIDPInitiated: allowIDPInitiated,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// samlResponse describes a response for testIDPResponse to build.
+type samlResponse struct {
+	inResponseTo string // empty for IdP-initiated
+	audience     string // empty for the SP's entity ID
+	nameIDFormat saml.NameIDFormat
+	issued       time.Time // zero for now
+	unsigned     bool      // strip every signature
+}
+
+// testIDPResponse has idp answer sp with a base64 SAMLResponse, as the IdP
+// would post it to the ACS.
+func testIDPResponse(t *testing.T, idp *saml.IdentityProvider, sp *SAML, opts samlResponse) string {
+	t.Helper()
+	md := *sp.sp.Metadata()
+	if opts.audience != "" {
+		md.EntityID = opts.audience
+	}
+	spsso := md.SPSSODescriptors[0]
+	if opts.unsigned {
+		// keep the assertion in clear so its signature can be stripped
+		spsso.KeyDescriptors = nil
+	}
+	req := &saml.IdpAuthnRequest{
+		IDP:                     idp,
+		HTTPRequest:             httptest.NewRequest("GET", idpRoot+"/sso", nil),
+		Request:                 saml.AuthnRequest{ID: opts.inResponseTo},
+		ServiceProviderMetadata: &md,
+		SPSSODescriptor:         &spsso,
+		Now:                     opts.issued,
+	}
+	if req.Now.IsZero() {
+		req.Now = saml.TimeNow()
+	}
+	for i, acs := range spsso.AssertionConsumerServices {
+		if acs.Binding == saml.HTTPPostBinding {
+			req.ACSEndpoint = &spsso.AssertionConsumerServices[i]
+		}
+	}
+	format := opts.nameIDFormat
+	if format == "" {
+		format = saml.PersistentNameIDFormat
+	}
+	if err := (saml.DefaultAssertionMaker{}).MakeAssertion(req, &saml.Session{
+		ID:           "session-1",
+		NameID:       "persistent-sam",
+		NameIDFormat: string(format),
+		UserName:     "sam",
+		UserEmail:    "sam@corp.example.com",
+		CreateTime:   req.Now,
+		ExpireTime:   req.Now.Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	form, err := req.PostBinding()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.unsigned {
+		return form.SAMLResponse
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(form.SAMLResponse)
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, sig := range doc.FindElements("//Signature") {
+		sig.Parent().RemoveChild(sig)
+	}
+	raw, err = doc.WriteToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// postToACS has sp parse samlResponse as posted to its ACS.
+func postToACS(sp *SAML, samlResponse string, requestIDs []string) (*Claims, error) {
+	r := httptest.NewRequest("POST", spRoot+"/auth/saml/acs", strings.NewReader(url.Values{"SAMLResponse": {samlResponse}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := r.ParseForm(); err != nil {
+		panic(err)
+	}
+	return sp.ParseResponse(r, requestIDs)
+}
+
+func TestSAMLParseResponse(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSAML(t, idp, false)
+	impostor := newTestIDP(t) // same entity ID, different key
+
+	tests := []struct {
+		name       string
+		idp        *saml.IdentityProvider
+		response   samlResponse
+		requestIDs []string
+		wantErr    string
+	}{
+		{name: "signed", idp: idp, response: samlResponse{inResponseTo: "id-1"}, requestIDs: []string{"id-1"}},
+		{name: "unsigned", idp: idp, response: samlResponse{inResponseTo: "id-1", unsigned: true}, requestIDs: []string{"id-1"}, wantErr: "signature element not present"},
+		{name: "signed by another key", idp: impostor, response: samlResponse{inResponseTo: "id-1"}, requestIDs: []string{"id-1"}, wantErr: "cannot validate signature"},
+		{name: "wrong audience", idp: idp, response: samlResponse{inResponseTo: "id-1", audience: "https://other.example.com/metadata"}, requestIDs: []string{"id-1"}, wantErr: "AudienceRestriction"},
+		{name: "expired", idp: idp, response: samlResponse{inResponseTo: "id-1", issued: time.Now().Add(-time.Hour)}, requestIDs: []string{"id-1"}, wantErr: "expired"},
+		{name: "answers another request", idp: idp, response: samlResponse{inResponseTo: "id-2"}, requestIDs: []string{"id-1"}, wantErr: "InResponseTo"},
+		{name: "IdP-initiated", idp: idp, response: samlResponse{}, wantErr: "InResponseTo"},
+		{name: "transient subject", idp: idp, response: samlResponse{inResponseTo: "id-1", nameIDFormat: saml.TransientNameIDFormat}, requestIDs: []string{"id-1"}, wantErr: "no persistent subject"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := postToACS(sp, testIDPResponse(t, tt.idp, sp, tt.response), tt.requestIDs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Claims{Subject: "persistent-sam", Username: "sam", Email: "sam@corp.example.com", EmailVerified: true}
+			if *c != want {
+				t.Errorf("claims = %+v, want %+v", *c, want)
+			}
+		})
+	}
+}
+
+func TestSAMLReplay(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSAML(t, idp, false)
+
+	resp := testIDPResponse(t, idp, sp, samlResponse{inResponseTo: "id-1"})
+	if _, err := postToACS(sp, resp, []string{"id-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := postToACS(sp, resp, []string{"id-1"}); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("replayed response: err = %v", err)
+	}
+}
+
+func TestSAMLAllowIDPInitiated(t *testing.T) {
+	idp := newTestIDP(t)
+	sp := newTestSAML(t, idp, true)
+
+	resp := testIDPResponse(t, idp, sp, samlResponse{})
+	if _, err := postToACS(sp, resp, nil); err != nil {
+		t.Fatal(err)
+	}
+	// unsolicited responses are still single-use and must be signed
+	if _, err := postToACS(sp, resp, nil); err == nil {
+		t.Fatal("replayed response accepted")
+	}
+	if _, err := postToACS(sp, testIDPResponse(t, idp, sp, samlResponse{unsigned: true}), nil); err == nil {
+		t.Fatal("unsigned response accepted")
+	}
+}
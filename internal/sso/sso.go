@@ -0,0 +1,143 @@
+// internal/sso/sso.go
+package sso
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pollAppNew/ent"
+	"pollAppNew/ent/identity"
+	"pollAppNew/ent/user"
+)
+
+var (
+	ssoOnly = flag.Bool("sso-only", false, "disable POST /signup and password login; users must sign in through SSO")
+)
+
+// SSOOnly reports whether password signup and login are disabled.
+func SSOOnly() bool {
+	return *ssoOnly
+}
+
+// Claims are the identity-provider attributes a local user is provisioned from.
+type Claims struct {
+	Subject       string
+	Username      string
+	Email         string
+	EmailVerified bool
+}
+
+var invalidUsernameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Provision returns the user linked to (provider, c.Subject), creating the
+// link on first login. When linkExisting is set, a first login whose email
+// the provider vouches for is linked to the local user who verified the same
+// address; the username is never trusted for this, since the provider's
+// users pick their own. Any other first login gets a new password-less user
+// (with a de-duplicated username).
+func Provision(ctx context.Context, client *ent.Client, provider string, c *Claims, linkExisting bool) (*ent.User, error) {
+	// 1) Known identity: done
+	id, err := client.Identity.
+		Query().
+		Where(identity.ProviderEQ(provider), identity.SubjectEQ(c.Subject)).
+		WithUser().
+		Only(ctx)
+	if err == nil {
+		return id.Edges.User, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, err
+	}
+
+	// 2) First login: find or create the local user and link it, atomically
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := provisionUser(ctx, tx.Client(), c, linkExisting)
+	if err == nil {
+		err = tx.Identity.
+			Create().
+			SetProvider(provider).
+			SetSubject(c.Subject).
+			SetUser(u).
+			SetEmail(c.Email).
+			Exec(ctx)
+	}
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func provisionUser(ctx context.Context, client *ent.Client, c *Claims, linkExisting bool) (*ent.User, error) {
+	base := usernameFrom(c)
+	if linkExisting && c.Email != "" && c.EmailVerified {
+		u, err := client.User.
+			Query().
+			Where(user.EmailEQ(strings.ToLower(c.Email)), user.EmailVerified(true)).
+			Only(ctx)
+		if err == nil {
+			return u, nil
+		}
+		if !ent.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	// pick the first free name among base, base-2, base-3, …
+	name := base
+	for i := 2; ; i++ {
+		taken, err := client.User.
+			Query().
+			Where(user.UsernameEQ(name)).
+			Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !taken {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	create := client.User.
+		Create().
+		SetUsername(name)
+
+	// adopt the provider's address if it vouches for it and nobody else has it
+	if c.Email != "" && c.EmailVerified {
+		email := strings.ToLower(c.Email)
+		taken, err := client.User.
+			Query().
+			Where(user.EmailEQ(email)).
+			Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !taken {
+			create.SetEmail(email).SetEmailVerified(true)
+		}
+	}
+	return create.Save(ctx)
+}
+
+// usernameFrom derives a local username from the claims, falling back to the
+// email's local part and finally to the subject.
+func usernameFrom(c *Claims) string {
+	candidates := []string{c.Username, strings.SplitN(c.Email, "@", 2)[0], "user-" + c.Subject}
+	for _, s := range candidates {
+		if s = invalidUsernameChars.ReplaceAllString(s, "-"); strings.Trim(s, "-") != "" {
+			return s
+		}
+	}
+	return "user"
+}
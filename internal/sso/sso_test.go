@@ -0,0 +1,52 @@
+package sso
+
+import (
+	"context"
+	"testing"
+
+	"pollAppNew/ent/enttest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestProvisionLinkExisting(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:provision?mode=memory&_fk=1")
+	defer client.Close()
+	admin := client.User.Create().
+		SetUsername("admin").
+		SetEmail("admin@example.com").
+		SetEmailVerified(true).
+		SaveX(ctx)
+	client.User.Create().
+		SetUsername("carol").
+		SetEmail("carol@example.com").
+		SaveX(ctx)
+
+	tests := []struct {
+		name   string
+		claims Claims
+		link   bool
+		want   int // 0 for a new user
+	}{
+		{"same username is not enough", Claims{Subject: "s1", Username: "admin"}, true, 0},
+		{"unverified email", Claims{Subject: "s2", Email: "admin@example.com"}, true, 0},
+		{"verified email", Claims{Subject: "s3", Email: "Admin@Example.com", EmailVerified: true}, true, admin.ID},
+		{"linking off", Claims{Subject: "s4", Email: "admin@example.com", EmailVerified: true}, false, 0},
+		{"local email unverified", Claims{Subject: "s5", Email: "carol@example.com", EmailVerified: true}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := Provision(ctx, client, "idp", &tt.claims, tt.link)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.want != 0 && u.ID != tt.want:
+				t.Errorf("linked to user %d, want %d", u.ID, tt.want)
+			case tt.want == 0 && (u.ID == admin.ID || u.Username == "admin"):
+				t.Errorf("claimed the admin account")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// internal/tally/irv.go
+package tally
+
+// IRVTieBreak describes how IRV picks among options tied for fewest votes.
+const IRVTieBreak = "the tied option with fewer votes in the latest earlier round where they differ is eliminated; " +
+	"if they were tied in every round, the one listed last is eliminated"
+
+// Round is one count of an instant-runoff tally.
+type Round struct {
+	Number int `json:"round"`
+	// Counts holds the votes of each option still in the running.
+	Counts []Count `json:"counts"`
+	// Exhausted counts ballots that rank none of those options.
+	Exhausted int `json:"exhausted"`
+	// Eliminated is the option dropped after this round, 0 in the last.
+	Eliminated int `json:"eliminated,omitempty"`
+	// TieBroken is set when Eliminated was picked by IRVTieBreak.
+	TieBroken bool `json:"tie_broken,omitempty"`
+}
+
+// IRVResult is an instant-runoff tally.
+type IRVResult struct {
+	// Winner is 0 when no ballot ranks any option.
+	Winner   int     `json:"winner,omitempty"`
+	Ballots  int     `json:"ballots"`
+	Rounds   []Round `json:"rounds"`
+	TieBreak string  `json:"tie_break"`
+}
+
+// IRV runs an instant-runoff count. Each round every ballot counts for its
+// highest-ranked option still in the running; an option holding a majority
+// of the ballots not yet exhausted wins, otherwise the option with the
+// fewest votes is eliminated and the count repeats.
+func IRV(options []int, ballots []Ballot) IRVResult {
+	res := IRVResult{Ballots: len(ballots), Rounds: []Round{}, TieBreak: IRVTieBreak}
+	running := append([]int(nil), options...)
+	var history []map[int]int
+
+	for n := 1; len(running) > 0; n++ {
+		// 1) Count each ballot for its top option still running
+		votes := make(map[int]int, len(running))
+		exhausted := 0
+		for _, b := range ballots {
+			if top, ok := firstRunning(b, running); ok {
+				votes[top]++
+			} else {
+				exhausted++
+			}
+		}
+		history = append(history, votes)
+		round := Round{Number: n, Counts: counts(running, votes), Exhausted: exhausted}
+
+		// 2) Stop at a majority, at the last option, or with nothing to count
+		active := len(ballots) - exhausted
+		if active == 0 {
+			res.Rounds = append(res.Rounds, round)
+			return res
+		}
+		for _, o := range running {
+			if 2*votes[o] > active || len(running) == 1 {
+				res.Winner = o
+				res.Rounds = append(res.Rounds, round)
+				return res
+			}
+		}
+
+		// 3) Eliminate the weakest option
+		round.Eliminated, round.TieBroken = weakest(running, history)
+		res.Rounds = append(res.Rounds, round)
+		running = remove(running, round.Eliminated)
+	}
+	return res
+}
+
+// weakest picks the running option with the fewest votes in the latest
+// round, applying IRVTieBreak among ties.
+func weakest(running []int, history []map[int]int) (int, bool) {
+	tied := running
+	for i := len(history) - 1; i >= 0 && len(tied) > 1; i-- {
+		tied = fewest(tied, history[i])
+	}
+	// running keeps the listed order, so the last tied option was listed last
+	return tied[len(tied)-1], len(fewest(running, history[len(history)-1])) > 1
+}
+
+// fewest returns the options of among with the fewest votes.
+func fewest(among []int, votes map[int]int) []int {
+	var out []int
+	for _, o := range among {
+		switch {
+		case len(out) == 0 || votes[o] < votes[out[0]]:
+			out = []int{o}
+		case votes[o] == votes[out[0]]:
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func firstRunning(b Ballot, running []int) (int, bool) {
+	for _, o := range b {
+		for _, r := range running {
+			if o == r {
+				return o, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func remove(options []int, o int) []int {
+	out := make([]int, 0, len(options)-1)
+	for _, x := range options {
+		if x != o {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+// internal/tally/schulze.go
+package tally
+
+// Rank places an option in a full ranking; tied options share a rank.
+type Rank struct {
+	Rank     int `json:"rank"`
+	OptionID int `json:"option_id"`
+}
+
+// SchulzeResult is a Condorcet tally by the Schulze method. Its matrices
+// are indexed like Options, so members can check the outcome by hand.
+type SchulzeResult struct {
+	Options []int `json:"options"`
+	// Pairwise[i][j] counts ballots preferring Options[i] to Options[j].
+	Pairwise [][]int `json:"pairwise"`
+	// Strongest[i][j] is the strength of the strongest path from
+	// Options[i] to Options[j], 0 when there is none.
+	Strongest [][]int `json:"strongest_paths"`
+	// CondorcetWinner beats every other option head to head; 0 if none does.
+	CondorcetWinner int `json:"condorcet_winner,omitempty"`
+	// Cycle lists options that each beat the next head to head, the last
+	// beating the first; empty when majorities are transitive.
+	Cycle []int `json:"cycle,omitempty"`
+	// Winners holds every Schulze winner; more than one means a tie.
+	Winners []int  `json:"winners"`
+	Ranking []Rank `json:"ranking"`
+}
+
+// Schulze runs a Schulze tally. A ballot prefers each option it ranks to
+// those ranked lower and to those it leaves out.
+func Schulze(options []int, ballots []Ballot) SchulzeResult {
+	n := len(options)
+	index := make(map[int]int, n)
+	for i, o := range options {
+		index[o] = i
+	}
+
+	// 1) Pairwise preferences
+	d := matrix(n)
+	for _, b := range ballots {
+		ranked := make([]bool, n)
+		for _, o := range b {
+			i, ok := index[o]
+			if !ok {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if j != i && !ranked[j] {
+					d[i][j]++
+				}
+			}
+			ranked[i] = true
+		}
+	}
+
+	// 2) Strongest paths (widest paths over winning pairs)
+	p := matrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && i != k && j != k {
+					p[i][j] = max(p[i][j], min(p[i][k], p[k][j]))
+				}
+			}
+		}
+	}
+
+	res := SchulzeResult{
+		Options:   options,
+		Pairwise:  d,
+		Strongest: p,
+		Winners:   []int{},
+	}
+	for _, i := range cycle(d) {
+		res.Cycle = append(res.Cycle, options[i])
+	}
+
+	// 3) Condorcet winner
+	for i := 0; i < n; i++ {
+		condorcet := true
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] <= d[j][i] {
+				condorcet = false
+			}
+		}
+		if condorcet {
+			res.CondorcetWinner = options[i]
+		}
+	}
+
+	// 4) Schulze winners and the full ranking: the options no other option
+	// beats by strongest path rank first, then those left unbeaten once
+	// they are removed, and so on; ties keep the listed order
+	left := make([]bool, n)
+	for i := range left {
+		left[i] = true
+	}
+	for placed := 0; placed < n; {
+		var tier []int
+		for i := 0; i < n; i++ {
+			if left[i] && unbeaten(p, left, i) {
+				tier = append(tier, i)
+			}
+		}
+		for _, i := range tier {
+			left[i] = false
+			res.Ranking = append(res.Ranking, Rank{Rank: placed + 1, OptionID: options[i]})
+			if placed == 0 {
+				res.Winners = append(res.Winners, options[i])
+			}
+		}
+		placed += len(tier)
+	}
+	return res
+}
+
+// cycle finds the indexes of options a1..ak where each beats the next
+// head to head and ak beats a1, searching from the first listed option.
+func cycle(d [][]int) []int {
+	n := len(d)
+	const (
+		unseen = iota
+		onPath
+		done
+	)
+	state := make([]int, n)
+	var path []int
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		state[i] = onPath
+		path = append(path, i)
+		for j := 0; j < n; j++ {
+			if j == i || d[i][j] <= d[j][i] {
+				continue
+			}
+			switch state[j] {
+			case onPath:
+				for k, x := range path {
+					if x == j {
+						return append([]int(nil), path[k:]...)
+					}
+				}
+			case unseen:
+				if c := visit(j); c != nil {
+					return c
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		if state[i] == unseen {
+			if c := visit(i); c != nil {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// unbeaten reports whether no option still left has a stronger path to
+// option i than i has back to it. The Schulze relation is transitive, so
+// some option left is always unbeaten.
+func unbeaten(p [][]int, left []bool, i int) bool {
+	for j := range p {
+		if left[j] && p[j][i] > p[i][j] {
+			return false
+		}
+	}
+	return true
+}
+
+func matrix(n int) [][]int {
+	m := make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+	}
+	return m
+}
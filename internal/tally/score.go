@@ -0,0 +1,105 @@
+// internal/tally/score.go
+package tally
+
+import "sort"
+
+// STARTieBreak describes how ties are settled in a score or STAR tally.
+const STARTieBreak = "finalists tied on total score, and a runoff tied on preferences, go to the higher total score, " +
+	"then to the option listed first"
+
+// Ratings is one voter's score for each option, by ID.
+type Ratings map[int]int
+
+// OptionScore summarizes the scores one option received.
+type OptionScore struct {
+	OptionID int     `json:"option_id"`
+	Total    int     `json:"total"`
+	Average  float64 `json:"average"`
+	// Distribution[i] counts ballots scoring the option Min+i.
+	Distribution []int `json:"distribution"`
+}
+
+// Runoff is STAR's automatic runoff between the two highest totals.
+type Runoff struct {
+	Finalists [2]int `json:"finalists"`
+	// Preferred[i] counts ballots scoring Finalists[i] above the other.
+	Preferred    [2]int `json:"preferred"`
+	NoPreference int    `json:"no_preference"`
+	Winner       int    `json:"winner"`
+}
+
+// ScoreResult is a score tally, with a runoff for STAR.
+type ScoreResult struct {
+	Min     int           `json:"min"`
+	Max     int           `json:"max"`
+	Ballots int           `json:"ballots"`
+	Scores  []OptionScore `json:"scores"`
+	// Winner has the highest total, or wins the runoff under STAR; 0
+	// without ballots.
+	Winner   int     `json:"winner,omitempty"`
+	Runoff   *Runoff `json:"runoff,omitempty"`
+	TieBreak string  `json:"tie_break"`
+}
+
+// Score totals ratings on the scale scaleMin..scaleMax. With star set,
+// the two options with the highest totals go to a runoff decided by how
+// many ballots prefer each.
+func Score(options []int, ballots []Ratings, scaleMin, scaleMax int, star bool) ScoreResult {
+	res := ScoreResult{
+		Min:      scaleMin,
+		Max:      scaleMax,
+		Ballots:  len(ballots),
+		Scores:   make([]OptionScore, len(options)),
+		TieBreak: STARTieBreak,
+	}
+
+	// 1) Totals, averages and distributions
+	for i, o := range options {
+		s := OptionScore{OptionID: o, Distribution: make([]int, scaleMax-scaleMin+1)}
+		for _, b := range ballots {
+			v, ok := b[o]
+			if !ok || v < scaleMin || v > scaleMax {
+				continue
+			}
+			s.Total += v
+			s.Distribution[v-scaleMin]++
+		}
+		if len(ballots) > 0 {
+			s.Average = float64(s.Total) / float64(len(ballots))
+		}
+		res.Scores[i] = s
+	}
+	if len(ballots) == 0 || len(options) == 0 {
+		return res
+	}
+
+	// 2) Rank by total; the sort is stable so ties keep the listed order
+	order := make([]OptionScore, len(res.Scores))
+	copy(order, res.Scores)
+	sort.SliceStable(order, func(i, j int) bool { return order[i].Total > order[j].Total })
+	res.Winner = order[0].OptionID
+	if !star || len(order) < 2 {
+		return res
+	}
+
+	// 3) Runoff between the top two
+	a, b := order[0].OptionID, order[1].OptionID
+	r := &Runoff{Finalists: [2]int{a, b}, Winner: a}
+	for _, bl := range ballots {
+		switch {
+		case bl[a] > bl[b]:
+			r.Preferred[0]++
+		case bl[b] > bl[a]:
+			r.Preferred[1]++
+		default:
+			r.NoPreference++
+		}
+	}
+	// a leads on total score, or on the listed order, so ties go to it
+	if r.Preferred[1] > r.Preferred[0] {
+		r.Winner = b
+	}
+	res.Runoff = r
+	res.Winner = r.Winner
+	return res
+}
@@ -0,0 +1,21 @@
+// internal/tally/tally.go
+package tally
+
+// Ballot is one voter's ranking of options by ID, most preferred first.
+// Options it leaves out rank below all those it names.
+type Ballot []int
+
+// Count is the votes of one option.
+type Count struct {
+	OptionID int `json:"option_id"`
+	Votes    int `json:"votes"`
+}
+
+// counts lists votes for options in the given order.
+func counts(options []int, votes map[int]int) []Count {
+	out := make([]Count, len(options))
+	for i, o := range options {
+		out[i] = Count{OptionID: o, Votes: votes[o]}
+	}
+	return out
+}
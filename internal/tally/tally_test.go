@@ -0,0 +1,231 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ballots expands counts of ballots written as strings of option letters,
+// A for option 1, B for option 2 and so on.
+func ballots(groups map[string]int) []Ballot {
+	var out []Ballot
+	for s, n := range groups {
+		b := Ballot{}
+		for _, c := range s {
+			b = append(b, int(c-'A')+1)
+		}
+		for range n {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func TestSchulze(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []int
+		ballots       []Ballot
+		wantWinners   []int
+		wantRanking   []Rank
+		wantCondorcet int
+		wantCycle     bool
+	}{
+		{
+			name:    "example with a cycle",
+			options: []int{1, 2, 3, 4, 5},
+			ballots: ballots(map[string]int{
+				"ACBED": 5, "ADECB": 5, "BEDAC": 8, "CABED": 3,
+				"CAEBD": 7, "CBADE": 2, "DCEBA": 7, "EBADC": 8,
+			}),
+			wantWinners: []int{5},
+			wantRanking: []Rank{{1, 5}, {2, 1}, {3, 3}, {4, 2}, {5, 4}},
+			wantCycle:   true,
+		},
+		{
+			name:          "Condorcet winner",
+			options:       []int{1, 2, 3},
+			ballots:       []Ballot{{1, 2}, {1}, {2, 3}},
+			wantWinners:   []int{1},
+			wantRanking:   []Rank{{1, 1}, {2, 2}, {3, 3}},
+			wantCondorcet: 1,
+		},
+		{
+			// 2 beats 3, but nothing beats 1 and 1 beats nothing
+			name:        "unbeaten option that beats nobody",
+			options:     []int{1, 2, 3},
+			ballots:     []Ballot{{1, 2, 3}, {2, 3, 1}},
+			wantWinners: []int{1, 2},
+			wantRanking: []Rank{{1, 1}, {1, 2}, {3, 3}},
+		},
+		{
+			name:        "tie keeps the listed order",
+			options:     []int{2, 1},
+			ballots:     []Ballot{{1}, {2}},
+			wantWinners: []int{2, 1},
+			wantRanking: []Rank{{1, 2}, {1, 1}},
+		},
+		{
+			name:        "no ballots",
+			options:     []int{1, 2},
+			wantWinners: []int{1, 2},
+			wantRanking: []Rank{{1, 1}, {1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Schulze(tt.options, tt.ballots)
+			if !reflect.DeepEqual(r.Winners, tt.wantWinners) {
+				t.Errorf("winners = %v, want %v", r.Winners, tt.wantWinners)
+			}
+			if !reflect.DeepEqual(r.Ranking, tt.wantRanking) {
+				t.Errorf("ranking = %v, want %v", r.Ranking, tt.wantRanking)
+			}
+			if r.CondorcetWinner != tt.wantCondorcet {
+				t.Errorf("Condorcet winner = %d, want %d", r.CondorcetWinner, tt.wantCondorcet)
+			}
+			if (len(r.Cycle) > 0) != tt.wantCycle {
+				t.Errorf("cycle = %v", r.Cycle)
+			}
+		})
+	}
+}
+
+func TestIRV(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        []int
+		ballots        []Ballot
+		wantWinner     int
+		wantEliminated []int // per round, 0 for the last
+		wantTieBroken  []bool
+	}{
+		{
+			name:           "transfers decide",
+			options:        []int{1, 2, 3, 4},
+			ballots:        ballots(map[string]int{"AB": 8, "BC": 7, "CB": 5, "D": 2}),
+			wantWinner:     2,
+			wantEliminated: []int{4, 3, 0},
+			wantTieBroken:  []bool{false, false, false},
+		},
+		{
+			name:           "majority in the first round",
+			options:        []int{1, 2},
+			ballots:        ballots(map[string]int{"A": 2, "B": 1}),
+			wantWinner:     1,
+			wantEliminated: []int{0},
+			wantTieBroken:  []bool{false},
+		},
+		{
+			// 2 and 3 tie in round 2; 2 had fewer votes in round 1
+			name:           "tie broken on an earlier round",
+			options:        []int{1, 2, 3, 4},
+			ballots:        ballots(map[string]int{"A": 4, "B": 2, "C": 3, "DB": 1}),
+			wantWinner:     1,
+			wantEliminated: []int{4, 2, 0},
+			wantTieBroken:  []bool{false, true, false},
+		},
+		{
+			name:           "tied in every round",
+			options:        []int{1, 2},
+			ballots:        []Ballot{{1}, {2}},
+			wantWinner:     1,
+			wantEliminated: []int{2, 0},
+			wantTieBroken:  []bool{true, false},
+		},
+		{
+			name:           "no ballots",
+			options:        []int{1, 2},
+			wantEliminated: []int{0},
+			wantTieBroken:  []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := IRV(tt.options, tt.ballots)
+			if r.Winner != tt.wantWinner {
+				t.Errorf("winner = %d, want %d", r.Winner, tt.wantWinner)
+			}
+			var eliminated []int
+			var tieBroken []bool
+			for _, rd := range r.Rounds {
+				eliminated = append(eliminated, rd.Eliminated)
+				tieBroken = append(tieBroken, rd.TieBroken)
+			}
+			if !reflect.DeepEqual(eliminated, tt.wantEliminated) || !reflect.DeepEqual(tieBroken, tt.wantTieBroken) {
+				t.Errorf("eliminated %v, tie broken %v; want %v, %v", eliminated, tieBroken, tt.wantEliminated, tt.wantTieBroken)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		ballots    []Ratings
+		star       bool
+		wantTotals []int
+		wantWinner int
+		wantRunoff *Runoff
+	}{
+		{
+			name:       "highest total wins",
+			ballots:    []Ratings{{1: 5, 2: 4, 3: 0}, {1: 0, 2: 4, 3: 5}},
+			wantTotals: []int{5, 8, 5},
+			wantWinner: 2,
+		},
+		{
+			name:       "runoff overturns the totals",
+			ballots:    []Ratings{{1: 5, 2: 0}, {1: 3, 2: 4}, {1: 3, 2: 4}},
+			star:       true,
+			wantTotals: []int{11, 8, 0},
+			wantWinner: 2,
+			wantRunoff: &Runoff{Finalists: [2]int{1, 2}, Preferred: [2]int{1, 2}, Winner: 2},
+		},
+		{
+			name:       "tied runoff goes to the higher total",
+			ballots:    []Ratings{{1: 5, 2: 0}, {1: 0, 2: 1}, {1: 2, 2: 2}},
+			star:       true,
+			wantTotals: []int{7, 3, 0},
+			wantWinner: 1,
+			wantRunoff: &Runoff{Finalists: [2]int{1, 2}, Preferred: [2]int{1, 1}, NoPreference: 1, Winner: 1},
+		},
+		{
+			name:       "tied totals go to the option listed first",
+			ballots:    []Ratings{{2: 3, 3: 3}},
+			star:       true,
+			wantTotals: []int{0, 3, 3},
+			wantWinner: 2,
+			wantRunoff: &Runoff{Finalists: [2]int{2, 3}, NoPreference: 1, Winner: 2},
+		},
+		{
+			name:       "out-of-scale scores are ignored",
+			ballots:    []Ratings{{1: 9, 2: 1}},
+			wantTotals: []int{0, 1, 0},
+			wantWinner: 2,
+		},
+		{
+			name:       "no ballots",
+			star:       true,
+			wantTotals: []int{0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Score([]int{1, 2, 3}, tt.ballots, 0, 5, tt.star)
+			var totals []int
+			for _, s := range r.Scores {
+				totals = append(totals, s.Total)
+			}
+			if !reflect.DeepEqual(totals, tt.wantTotals) {
+				t.Errorf("totals = %v, want %v", totals, tt.wantTotals)
+			}
+			if r.Winner != tt.wantWinner {
+				t.Errorf("winner = %d, want %d", r.Winner, tt.wantWinner)
+			}
+			if !reflect.DeepEqual(r.Runoff, tt.wantRunoff) {
+				t.Errorf("runoff = %+v, want %+v", r.Runoff, tt.wantRunoff)
+			}
+		})
+	}
+}
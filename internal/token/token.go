@@ -0,0 +1,218 @@
+// internal/token/token.go
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	accessTTL   = flag.Duration("access-token-ttl", 15*time.Minute, "lifetime of bearer access tokens")
+	rotateEvery = flag.Duration("jwt-rotate-every", 24*time.Hour, "how often a new access-token signing key is generated")
+	issuer      = flag.String("jwt-issuer", "pollapp", "iss claim of issued access tokens")
+)
+
+// ErrInvalid is returned for malformed, forged or expired tokens.
+var ErrInvalid = errors.New("invalid access token")
+
+// Claims are the fields carried by an access token.
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	SessionID int    `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// UserID returns the numeric user ID in the sub claim.
+func (c *Claims) UserID() (int, error) {
+	return strconv.Atoi(c.Subject)
+}
+
+type signingKey struct {
+	id      string
+	priv    ed25519.PrivateKey
+	retired time.Time // zero while the key is the active signer
+}
+
+// KeySet signs access tokens with the newest Ed25519 key and verifies them
+// against every key that may still have unexpired tokens in circulation.
+// Keys live in memory only: after a restart clients simply use their refresh
+// token to obtain an access token signed by the new key.
+type KeySet struct {
+	mu   sync.RWMutex
+	keys []*signingKey // newest first
+}
+
+// NewKeySet returns a key set with a freshly generated active key.
+func NewKeySet() (*KeySet, error) {
+	ks := &KeySet{}
+	if err := ks.Rotate(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Rotate makes a new key the active signer. The previous key keeps verifying
+// until every token it signed has expired, then it is dropped.
+func (ks *KeySet) Rotate() error {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	now := time.Now()
+	kept := []*signingKey{{id: hex.EncodeToString(kid), priv: priv}}
+	for _, k := range ks.keys {
+		if k.retired.IsZero() {
+			k.retired = now
+		}
+		if now.Sub(k.retired) < *accessTTL {
+			kept = append(kept, k)
+		}
+	}
+	ks.keys = kept
+	return nil
+}
+
+// RunRotation rotates the key set on the -jwt-rotate-every interval until ctx is done.
+func (ks *KeySet) RunRotation(ctx context.Context) {
+	t := time.NewTicker(*rotateEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := ks.Rotate(); err != nil {
+				log.Printf("failed rotating signing key: %v", err)
+			}
+		}
+	}
+}
+
+// Issue signs an access token for userID bound to the session sessionID.
+func (ks *KeySet) Issue(userID, sessionID int) (string, time.Time, error) {
+	ks.mu.RLock()
+	k := ks.keys[0]
+	ks.mu.RUnlock()
+
+	now := time.Now()
+	exp := now.Add(*accessTTL)
+	header, err := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT", "kid": k.id})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	payload, err := json.Marshal(Claims{
+		Issuer:    *issuer,
+		Subject:   strconv.Itoa(userID),
+		SessionID: sessionID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: exp.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signingInput := b64(header) + "." + b64(payload)
+	sig := ed25519.Sign(k.priv, []byte(signingInput))
+	return signingInput + "." + b64(sig), exp, nil
+}
+
+// Verify checks the signature, issuer and expiry of raw and returns its claims.
+func (ks *KeySet) Verify(raw string) (*Claims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalid
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "EdDSA" {
+		return nil, ErrInvalid
+	}
+	pub := ks.publicKey(header.Kid)
+	if pub == nil {
+		return nil, ErrInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(pub, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, ErrInvalid
+	}
+	var c Claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, ErrInvalid
+	}
+	if c.Issuer != *issuer || time.Now().Unix() >= c.ExpiresAt {
+		return nil, ErrInvalid
+	}
+	return &c, nil
+}
+
+// JWK is the public half of a signing key in RFC 8037 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS returns the public keys tokens may currently be verified with.
+func (ks *KeySet) JWKS() []JWK {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	out := make([]JWK, len(ks.keys))
+	for i, k := range ks.keys {
+		out[i] = JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   b64(k.priv.Public().(ed25519.PublicKey)),
+			Kid: k.id,
+			Use: "sig",
+			Alg: "EdDSA",
+		}
+	}
+	return out
+}
+
+func (ks *KeySet) publicKey(kid string) ed25519.PublicKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for _, k := range ks.keys {
+		if k.id == kid {
+			return k.priv.Public().(ed25519.PublicKey)
+		}
+	}
+	return nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -6,13 +6,32 @@ import (
 	"log"
 	"net/http"
 
+	"pollAppNew/internal/account"
+	"pollAppNew/internal/authn"
+	"pollAppNew/internal/authz"
 	"pollAppNew/internal/db"
+	"pollAppNew/internal/lifecycle"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/mfa"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/session"
+	"pollAppNew/internal/sso"
+	"pollAppNew/internal/token"
 	"pollAppNew/router"
 )
 
 func main() {
 	// Parse command-line flags
 	flag.Parse()
+	if err := mfa.CheckPolicy(); err != nil {
+		log.Fatal(err)
+	}
+	if err := account.CheckPolicy(); err != nil {
+		log.Fatal(err)
+	}
+	if err := session.CheckSameSite(); err != nil {
+		log.Fatal(err)
+	}
 
 	client := db.NewClient()
 	defer client.Close()
@@ -31,7 +50,42 @@ func main() {
 		log.Printf("skipping seed; %d users already exist\n", userCount)
 	}
 
-	r := router.Setup(client)
+	if err := authz.Bootstrap(ctx, client); err != nil {
+		log.Fatalf("failed granting admin roles: %v", err)
+	}
+
+	keys, err := token.NewKeySet()
+	if err != nil {
+		log.Fatalf("failed generating signing key: %v", err)
+	}
+	go keys.RunRotation(ctx)
+	go lifecycle.Run(ctx, client)
+
+	oidcProvider, err := sso.NewOIDC(ctx, sso.OIDCConfigFromFlags())
+	if err != nil {
+		log.Fatalf("failed configuring OIDC: %v", err)
+	}
+	samlProvider, err := sso.NewSAML(ctx, sso.SAMLConfigFromFlags())
+	if err != nil {
+		log.Fatalf("failed configuring SAML: %v", err)
+	}
+	if sso.SSOOnly() && oidcProvider == nil && samlProvider == nil {
+		log.Fatal("-sso-only requires an SSO provider, e.g. -oidc-issuer or -saml-idp-metadata-url")
+	}
+
+	mailer, err := mail.FromFlags()
+	if err != nil {
+		log.Fatalf("failed configuring mailer: %v", err)
+	}
+
+	authenticator, err := authn.FromFlags(client)
+	if err != nil {
+		log.Fatalf("failed configuring authentication: %v", err)
+	}
+
+	limits := ratelimit.FromFlags(ratelimit.NewMemoryStore())
+
+	r := router.Setup(client, authenticator, keys, mailer, limits, oidcProvider, samlProvider)
 	log.Println("Server running on :8080")
 	log.Fatal(http.ListenAndServe(":8080", r))
 }
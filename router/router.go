@@ -2,39 +2,143 @@
 package router
 
 import (
+	"net/http"
+
 	"pollAppNew/ent"
 
+	"pollAppNew/internal/auth"
+	"pollAppNew/internal/authn"
+	"pollAppNew/internal/authz"
+	"pollAppNew/internal/csrf"
 	"pollAppNew/internal/handler"
+	"pollAppNew/internal/mail"
+	"pollAppNew/internal/ratelimit"
+	"pollAppNew/internal/sso"
+	"pollAppNew/internal/token"
 
 	"github.com/julienschmidt/httprouter"
 )
 
-func Setup(client *ent.Client) *httprouter.Router {
+// Setup wires every route. oidcProvider and samlProvider may be nil when
+// the respective SSO protocol is not configured.
+func Setup(client *ent.Client, authenticator authn.Authenticator, keys *token.KeySet, mailer mail.Mailer, limits *ratelimit.Limits, oidcProvider *sso.OIDC, samlProvider *sso.SAML) http.Handler {
 	r := httprouter.New()
 
+	// route registers h, enforcing that personal access tokens hold scope.
+	route := func(method, path string, scope auth.Scope, h httprouter.Handle) {
+		r.Handle(method, path, auth.Scoped(scope, h))
+	}
+
 	// Auth routes
-	r.POST("/signup", handler.SignUp(client))
-	r.POST("/login", handler.Login(client))
+	route("POST", "/signup", auth.ScopeNone, auth.OptionalAuth(handler.SignUp(client, mailer, limits)))
+	route("POST", "/login", auth.ScopeNone, auth.OptionalAuth(handler.Login(client, authenticator, limits)))
+	route("POST", "/login/2fa", auth.ScopeNone, auth.OptionalAuth(handler.LoginMFA(client, limits)))
+	route("POST", "/auth/token", auth.ScopeNone, auth.OptionalAuth(handler.IssueToken(client, authenticator, keys, limits)))
+	route("GET", "/.well-known/jwks.json", auth.ScopeNone, handler.JWKS(keys))
+	if oidcProvider != nil {
+		route("GET", "/auth/oidc/login", auth.ScopeNone, handler.OIDCLogin(oidcProvider))
+		route("GET", "/auth/oidc/callback", auth.ScopeNone, auth.OptionalAuth(handler.OIDCCallback(client, oidcProvider)))
+	}
+	if samlProvider != nil {
+		route("GET", "/auth/saml/metadata", auth.ScopeNone, handler.SAMLMetadata(samlProvider))
+		route("GET", "/auth/saml/login", auth.ScopeNone, handler.SAMLLogin(samlProvider))
+		route("POST", "/auth/saml/acs", auth.ScopeNone, auth.OptionalAuth(handler.SAMLACS(client, samlProvider)))
+	}
+
+	// Account recovery routes
+	route("POST", "/verify-email", auth.ScopeNone, auth.OptionalAuth(handler.VerifyEmail(client)))
+	route("POST", "/verify-email/resend", auth.ScopeNone, auth.RequireAuth(handler.ResendVerification(client, mailer)))
+	route("POST", "/password/forgot", auth.ScopeNone, auth.OptionalAuth(handler.ForgotPassword(client, mailer)))
+	route("POST", "/password/reset", auth.ScopeNone, auth.OptionalAuth(handler.ResetPassword(client)))
+
+	// Account routes
+	route("GET", "/me", auth.ScopeUsersRead, auth.RequireAuthForMFASetup(handler.GetMe()))
+	route("PATCH", "/me", auth.ScopeNone, auth.RequireAuth(handler.UpdateMe(client, mailer)))
+	route("POST", "/me/password", auth.ScopeNone, auth.RequireAuth(handler.ChangePassword(client, limits)))
+	route("DELETE", "/me", auth.ScopeNone, auth.RequireAuthForMFASetup(handler.DeleteMe(client, limits)))
+
+	// Two-factor authentication routes; reachable before enrolling so that
+	// -require-2fa can be satisfied
+	route("GET", "/me/2fa", auth.ScopeNone, auth.RequireAuthForMFASetup(handler.MFAStatus(client)))
+	route("POST", "/me/2fa", auth.ScopeNone, auth.RequireAuthForMFASetup(handler.EnrollMFA(client)))
+	route("GET", "/me/2fa/qr.png", auth.ScopeNone, auth.RequireAuthForMFASetup(handler.MFAQRCode()))
+	route("POST", "/me/2fa/confirm", auth.ScopeNone, auth.RequireAuthForMFASetup(handler.ConfirmMFA(client)))
+	route("POST", "/me/2fa/recovery-codes", auth.ScopeNone, auth.RequireAuth(handler.RegenerateRecoveryCodes(client, limits)))
+	route("DELETE", "/me/2fa", auth.ScopeNone, auth.RequireAuth(handler.DisableMFA(client, limits)))
 
 	// Poll routes
-	r.POST("/polls", handler.CreatePoll(client))
-	r.GET("/polls/:id", handler.GetPoll(client))
-	r.GET("/polls", handler.ListPolls(client))
+	route("POST", "/polls", auth.ScopePollsWrite, auth.RequireAuth(handler.CreatePoll(client)))
+	route("GET", "/polls/:id", auth.ScopePollsRead, auth.OptionalAuth(handler.GetPoll(client)))
+	route("GET", "/polls", auth.ScopePollsRead, auth.OptionalAuth(handler.ListPolls(client)))
 
 	// Voting routes
-	r.POST("/polls/:id/vote", handler.Vote(client))
-	r.GET("/polls/:id/results", handler.GetResults(client))
-	r.GET("/polls/:id/results/:optionId/voters", handler.GetVoters(client))
+	route("POST", "/polls/:id/vote", auth.ScopeVotesWrite, auth.RequireAuth(handler.Vote(client)))
+	route("PUT", "/polls/:id/vote", auth.ScopeVotesWrite, auth.RequireAuth(handler.ChangeVote(client)))
+	route("DELETE", "/polls/:id/vote", auth.ScopeVotesWrite, auth.RequireAuth(handler.RetractVote(client)))
+	route("GET", "/polls/:id/vote/history", auth.ScopePollsRead, auth.RequireAuth(handler.GetVoteHistory(client)))
+	route("GET", "/polls/:id/results", auth.ScopePollsRead, auth.OptionalAuth(handler.GetResults(client)))
+	route("GET", "/polls/:id/results/:optionId/voters", auth.ScopePollsRead, auth.OptionalAuth(handler.GetVoters(client)))
 
 	//added
 	// User routes
-	r.GET("/users", handler.ListUsers(client))
+	route("GET", "/users", auth.ScopeUsersRead, auth.OptionalAuth(handler.ListUsers(client)))
+	route("GET", "/users/:id", auth.ScopeUsersRead, auth.OptionalAuth(handler.GetUser(client)))
 	// Logout route
-	r.POST("/logout", handler.Logout(client))
+	route("POST", "/logout", auth.ScopeNone, auth.OptionalAuth(handler.Logout(client)))
 	//Mdify poll route
-	r.PUT("/polls/:id", handler.UpdatePoll(client))
+	route("PUT", "/polls/:id", auth.ScopePollsWrite, auth.RequireAuth(handler.UpdatePoll(client)))
 	// Delete poll route
-	r.DELETE("/polls/:id", handler.DeletePoll(client))
+	route("DELETE", "/polls/:id", auth.ScopePollsWrite, auth.RequireAuth(handler.DeletePoll(client)))
+
+	// Lifecycle routes; closing and reopening are with the moderation routes
+	route("POST", "/polls/:id/publish", auth.ScopePollsWrite, auth.RequireAuth(handler.PublishPoll(client)))
+	route("POST", "/polls/:id/archive", auth.ScopePollsWrite, auth.RequireAuth(handler.ArchivePoll(client)))
+
+	// Visibility and invite routes
+	route("PUT", "/polls/:id/visibility", auth.ScopePollsWrite, auth.RequireAuth(handler.SetVisibility(client)))
+	route("GET", "/polls/:id/invites", auth.ScopePollsRead, auth.RequireAuth(handler.ListInvites(client)))
+	route("POST", "/polls/:id/invites", auth.ScopePollsWrite, auth.RequireAuth(handler.AddInvites(client)))
+	route("DELETE", "/polls/:id/invites/users/:userId", auth.ScopePollsWrite, auth.RequireAuth(handler.UninviteUser(client)))
+	route("DELETE", "/polls/:id/invites/groups/:groupId", auth.ScopePollsWrite, auth.RequireAuth(handler.UninviteGroup(client)))
+
+	// Session management routes
+	route("GET", "/sessions", auth.ScopeNone, auth.RequireAuth(handler.ListSessions(client)))
+	route("DELETE", "/sessions", auth.ScopeNone, auth.RequireAuth(handler.RevokeOtherSessions(client)))
+	route("DELETE", "/sessions/:id", auth.ScopeNone, auth.RequireAuth(handler.RevokeSession(client)))
+
+	// Personal access token routes
+	route("POST", "/tokens", auth.ScopeNone, auth.RequireAuth(handler.CreateAccessToken(client)))
+	route("GET", "/tokens", auth.ScopeNone, auth.RequireAuth(handler.ListAccessTokens(client)))
+	route("DELETE", "/tokens/:id", auth.ScopeNone, auth.RequireAuth(handler.RevokeAccessToken(client)))
+
+	// Moderation routes; handlers also let owners act on their own polls
+	route("POST", "/polls/:id/close", auth.ScopePollsWrite, auth.RequireAuth(handler.ClosePoll(client)))
+	route("POST", "/polls/:id/reopen", auth.ScopePollsWrite, auth.RequireAuth(handler.ReopenPoll(client)))
+	route("DELETE", "/admin/polls/:id", auth.ScopeNone, authz.Require(authz.PollDeleteAny, handler.DeletePoll(client)))
+	route("POST", "/admin/polls/:id/close", auth.ScopeNone, authz.Require(authz.PollCloseAny, handler.ClosePoll(client)))
+	route("POST", "/admin/polls/:id/reopen", auth.ScopeNone, authz.Require(authz.PollCloseAny, handler.ReopenPoll(client)))
+	route("POST", "/admin/users/:id/ban", auth.ScopeNone, authz.Require(authz.UserBan, handler.BanUser(client)))
+	route("DELETE", "/admin/users/:id/ban", auth.ScopeNone, authz.Require(authz.UserBan, handler.UnbanUser(client)))
+	route("PUT", "/admin/users/:id/role", auth.ScopeNone, authz.Require(authz.UserAssignRole, handler.SetUserRole(client)))
+	route("DELETE", "/admin/lockouts/:username", auth.ScopeNone, authz.Require(authz.AccountUnlock, handler.UnlockAccount(limits)))
+
+	// SCIM provisioning routes, for an admin's token with the scim scope
+	route("GET", "/scim/v2/ServiceProviderConfig", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.SCIMServiceProviderConfig()))
+	route("GET", "/scim/v2/Users", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.ListSCIMUsers(client)))
+	route("POST", "/scim/v2/Users", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.CreateSCIMUser(client)))
+	route("GET", "/scim/v2/Users/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.GetSCIMUser(client)))
+	route("PUT", "/scim/v2/Users/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.ReplaceSCIMUser(client)))
+	route("PATCH", "/scim/v2/Users/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.PatchSCIMUser(client)))
+	route("DELETE", "/scim/v2/Users/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.DeleteSCIMUser(client)))
+	route("GET", "/scim/v2/Groups", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.ListSCIMGroups(client)))
+	route("POST", "/scim/v2/Groups", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.CreateSCIMGroup(client)))
+	route("GET", "/scim/v2/Groups/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.GetSCIMGroup(client)))
+	route("PUT", "/scim/v2/Groups/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.ReplaceSCIMGroup(client)))
+	route("PATCH", "/scim/v2/Groups/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.PatchSCIMGroup(client)))
+	route("DELETE", "/scim/v2/Groups/:id", auth.ScopeSCIM, authz.Require(authz.UserProvision, handler.DeleteSCIMGroup(client)))
 
-	return r
+	// Resolve the caller once per request for the wrappers above, then
+	// check cookie-authenticated writes for forgery; the IdP posts to the
+	// ACS cross-site and the signed assertion is its own proof
+	return auth.Middleware(client, keys, csrf.Protect(r, "/auth/saml/acs"))
 }
@@ -6,16 +6,21 @@ import (
 	"log"
 
 	"pollAppNew/ent"
+	"pollAppNew/internal/password"
 )
 
 func seed(ctx context.Context, client *ent.Client) {
-	// 1️⃣ Create 10 users
+	// 1️⃣ Create 10 users, all sharing the same hashed password
+	hash, err := password.Hash("pass123")
+	if err != nil {
+		log.Fatalf("failed hashing seed password: %v", err)
+	}
 	users := make([]*ent.User, 0, 10)
 	for i := 1; i <= 10; i++ {
 		u, err := client.User.
 			Create().
 			SetUsername(fmt.Sprintf("user%02d", i)).
-			SetPasswordHash("pass123").
+			SetPasswordHash(hash).
 			Save(ctx)
 		if err != nil {
 			log.Fatalf("failed creating user %d: %v", i, err)